@@ -0,0 +1,109 @@
+// mls_convert converts an MLS Players Association salary release PDF into the tab separated
+// data file format, so releases older than the data directory can be added to it.
+//
+// The text of the PDF is extracted with pdftotext from poppler, which must be installed. A text
+// file already extracted with "pdftotext -layout" can be converted directly.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// columnGap separates the columns of a table laid out by pdftotext
+var columnGap = regexp.MustCompile(`\s{2,}|\t`)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of %s: [flags] release.pdf\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+// extract returns the layout preserving text of the named PDF, or the file itself if it isn't a PDF
+func extract(name string) ([]byte, error) {
+	if !strings.EqualFold(filepath.Ext(name), ".pdf") {
+		return os.ReadFile(name)
+	}
+	out, err := exec.Command("pdftotext", "-layout", name, "-").Output()
+	if err != nil {
+		return nil, fmt.Errorf("pdftotext %s: %v", name, err)
+	}
+	return out, nil
+}
+
+// tabulate returns the table rows of layout text as tab separated lines. Money cells like
+// "$ 60,000.00" are joined into one token.
+func tabulate(text []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("\t\n")
+	for _, line := range strings.Split(string(text), "\n") {
+		var cells []string
+		for _, cell := range columnGap.Split(strings.TrimSpace(line), -1) {
+			if strings.HasPrefix(cell, "$") {
+				cell = "$" + strings.TrimSpace(strings.TrimPrefix(cell, "$"))
+			}
+			if cell != "" {
+				cells = append(cells, cell)
+			}
+		}
+		if len(cells) > 0 {
+			buf.WriteString(strings.Join(cells, "\t") + "\n")
+		}
+	}
+	return buf.Bytes()
+}
+
+func main() {
+	var (
+		out   = flag.String("o", "", "output data file (default: standard output)")
+		debug = flag.Bool("debug", false, "print lines that don't match")
+	)
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 1 {
+		usage()
+		os.Exit(2)
+	}
+	name := flag.Arg(0)
+
+	text, err := extract(name)
+	check(err)
+	parser := mlsdata.NewParser(bytes.NewReader(tabulate(text)))
+	if *debug {
+		parser.Skipped = func(line int, text string) {
+			if text != "" {
+				log.Println("no match:", text)
+			}
+		}
+	}
+	players, err := parser.All()
+	check(err)
+	if len(players) == 0 {
+		log.Fatalf("%s: no players found", name)
+	}
+
+	buf := &bytes.Buffer{}
+	check(mlsdata.WriteData(buf, players))
+	if *out == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		check(err)
+		return
+	}
+	check(os.WriteFile(*out, buf.Bytes(), 0o644))
+	log.Printf("%s: %d players", *out, len(players))
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,225 @@
+// mls_diff compares two data files, such as a release and its mid-season correction, and reports
+// the players added, removed, renamed, and with changed base salary or guaranteed compensation.
+//
+// Players are matched by name key, at the same club first. Players left over on both sides at the same club whose names
+// fuzzy match are reported as renamed. Salary changes smaller than -min-change dollars or
+// -min-pct percent are ignored.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of %s: [flags] old_data new_data\n", os.Args[0])
+	flag.PrintDefaults()
+}
+
+// readPlayers returns the players of the named csv, xlsx, or text data file, leaving out header
+// lines that parse as players
+func readPlayers(name string) (mlsdata.Players, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	players, err := mlsdata.NewFileParser(name, f).All()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return players, nil
+}
+
+// Rename is a player whose name differs between the data files
+type Rename struct {
+	From, To mlsdata.Player
+}
+
+// Change is a changed salary value of a player
+type Change struct {
+	Player   mlsdata.Player
+	Field    string
+	From, To mlsdata.Money
+}
+
+// Diff is the difference between the players of two data files
+type Diff struct {
+	Added, Removed mlsdata.Players
+	Renamed        []Rename
+	Changed        []Change
+}
+
+// threshold decides which salary changes are reported
+type threshold struct {
+	min mlsdata.Money // least change in dollars
+	pct float64       // least change as a percentage of the old value
+}
+
+// counts returns true if the change from a to b meets the threshold
+func (t threshold) counts(a, b mlsdata.Money) bool {
+	delta := (b - a).Abs()
+	if delta == 0 || delta < t.min {
+		return false
+	}
+	return a == 0 || delta.Float()/a.Abs().Float()*100 >= t.pct
+}
+
+// diff returns the players added, removed, renamed, and with salary changes meeting t between
+// from and to
+func diff(from, to mlsdata.Players, t threshold) Diff {
+	var d Diff
+	// players sharing a name key are matched at the same club first
+	before := make(map[string][]int, len(from))
+	for i, p := range from {
+		key := mlsdata.NameKey(p.Name)
+		before[key] = append(before[key], i)
+	}
+	matched := make(map[int]bool, len(from))
+	match := func(p mlsdata.Player) (int, bool) {
+		found := -1
+		for _, i := range before[mlsdata.NameKey(p.Name)] {
+			if matched[i] {
+				continue
+			}
+			if found < 0 || from[i].Club == p.Club && from[found].Club != p.Club {
+				found = i
+			}
+		}
+		return found, found >= 0
+	}
+	changes := func(a, b mlsdata.Player) {
+		if t.counts(a.BaseSalary, b.BaseSalary) {
+			d.Changed = append(d.Changed, Change{Player: b, Field: "base salary", From: a.BaseSalary, To: b.BaseSalary})
+		}
+		if t.counts(a.Compensation, b.Compensation) {
+			d.Changed = append(d.Changed, Change{Player: b, Field: "compensation", From: a.Compensation, To: b.Compensation})
+		}
+	}
+	for _, p := range to {
+		i, ok := match(p)
+		if !ok {
+			d.Added = append(d.Added, p)
+			continue
+		}
+		matched[i] = true
+		changes(from[i], p)
+	}
+	for i, p := range from {
+		if !matched[i] {
+			d.Removed = append(d.Removed, p)
+		}
+	}
+
+	// a removed and an added player are a rename if each is the other's only fuzzy match at the club
+	matches := func(p mlsdata.Player, candidates mlsdata.Players) []int {
+		var found []int
+		for i, c := range candidates {
+			if c.Club == p.Club && (mlsdata.MatchName(c.Name, p.Name) || mlsdata.MatchName(p.Name, c.Name)) {
+				found = append(found, i)
+			}
+		}
+		return found
+	}
+	renamedFrom, renamedTo := make(map[int]bool), make(map[int]bool)
+	for i, r := range d.Removed {
+		m := matches(r, d.Added)
+		if len(m) != 1 || len(matches(d.Added[m[0]], d.Removed)) != 1 {
+			continue
+		}
+		a := d.Added[m[0]]
+		d.Renamed = append(d.Renamed, Rename{From: r, To: a})
+		renamedFrom[i], renamedTo[m[0]] = true, true
+		changes(r, a)
+	}
+	d.Removed = remove(d.Removed, renamedFrom)
+	d.Added = remove(d.Added, renamedTo)
+
+	byClub := func(p mlsdata.Players) {
+		sort.SliceStable(p, func(i, j int) bool { return p[i].Club < p[j].Club })
+	}
+	byClub(d.Added)
+	byClub(d.Removed)
+	sort.SliceStable(d.Changed, func(i, j int) bool {
+		return (d.Changed[i].To - d.Changed[i].From).Abs() > (d.Changed[j].To - d.Changed[j].From).Abs()
+	})
+	return d
+}
+
+// remove returns players without the indexes in drop
+func remove(players mlsdata.Players, drop map[int]bool) mlsdata.Players {
+	var kept mlsdata.Players
+	for i, p := range players {
+		if !drop[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func main() {
+	var (
+		minChange = flag.Float64("min-change", 0.01, "least change in dollars of a salary value to report")
+		minPct    = flag.Float64("min-pct", 0, "least change of a salary value to report, as a percentage of the old value")
+	)
+	log.SetFlags(0)
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() != 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	from, err := readPlayers(flag.Arg(0))
+	check(err)
+	to, err := readPlayers(flag.Arg(1))
+	check(err)
+	d := diff(from, to, threshold{min: mlsdata.Dollars(*minChange), pct: *minPct})
+
+	t := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, section := range []struct {
+		title   string
+		players mlsdata.Players
+	}{{"added", d.Added}, {"removed", d.Removed}} {
+		if len(section.players) == 0 {
+			continue
+		}
+		fmt.Fprintln(t, section.title)
+		for _, p := range section.players {
+			fmt.Fprintf(t, "  %s\t%s\t%s\t%s\n", p.Club, p.Pos.Label(), p.Name, p.Compensation)
+		}
+		fmt.Fprintln(t)
+	}
+	if len(d.Renamed) > 0 {
+		fmt.Fprintln(t, "renamed")
+		for _, r := range d.Renamed {
+			fmt.Fprintf(t, "  %s\t%s\t-> %s\n", r.To.Club, r.From.Name, r.To.Name)
+		}
+		fmt.Fprintln(t)
+	}
+	if len(d.Changed) > 0 {
+		fmt.Fprintln(t, "changed")
+		for _, c := range d.Changed {
+			delta, sign := c.To-c.From, "+"
+			if delta < 0 {
+				sign = "-"
+			}
+			fmt.Fprintf(t, "  %s\t%s\t%s\t%s\t-> %s\t%s%s\n", c.Player.Club, c.Player.Name, c.Field, c.From, c.To, sign, delta.Abs())
+		}
+		fmt.Fprintln(t)
+	}
+	fmt.Fprintf(t, "%d added, %d removed, %d renamed, %d salary changes\n", len(d.Added), len(d.Removed), len(d.Renamed), len(d.Changed))
+	check(t.Flush())
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
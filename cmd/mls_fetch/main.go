@@ -0,0 +1,134 @@
+// mls_fetch downloads the latest MLS Players Association salary release, converts it to the
+// tab separated data file format and writes it to the data directory as YYYY_MM_DD_data.
+//
+// The url may be the csv release itself or a page linking to it, in which case the first
+// csv link on the page is followed. -file imports a csv, xlsx, or text release from disk
+// instead, and -preview shows the parsed players and parse issues before writing the data file.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+var csvLink = regexp.MustCompile(`(?i)href="([^"]+\.csv(?:\?[^"]*)?)"`)
+
+// get returns the body, Last-Modified time, and content type of u
+func get(client *http.Client, u string) ([]byte, time.Time, string, error) {
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, time.Time{}, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, "", fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, time.Time{}, "", err
+	}
+	modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return b, modified, resp.Header.Get("Content-Type"), nil
+}
+
+// fetch returns the csv release at u, following the first csv link if u is a web page
+func fetch(client *http.Client, u string) ([]byte, time.Time, error) {
+	b, modified, contentType, err := get(client, u)
+	if err != nil || !strings.Contains(contentType, "html") {
+		return b, modified, err
+	}
+	m := csvLink.FindSubmatch(b)
+	if m == nil {
+		return nil, time.Time{}, fmt.Errorf("%s: no csv release linked", u)
+	}
+	base, err := url.Parse(u)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	link, err := base.Parse(string(m[1]))
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	b, modified, _, err = get(client, link.String())
+	return b, modified, err
+}
+
+func main() {
+	var (
+		src   = flag.String("url", "https://mlsplayers.org/resources/salary-guide", "MLSPA salary release csv, or a page linking to it")
+		dir   = flag.String("dir", "cmd/mls_salaries/data", "data directory")
+		date  = flag.String("date", "", "release date as YYYY-MM-DD (default: the release's Last-Modified date, or today)")
+		force = flag.Bool("f", false, "overwrite an existing data file")
+		file  = flag.String("file", "", "csv, xlsx, or tab separated release file to import instead of fetching -url")
+		show  = flag.Bool("preview", false, "show the detected format, first parsed players, and parse issue counts, and confirm before writing the data file")
+	)
+	log.SetFlags(0)
+	flag.Parse()
+
+	var (
+		b        []byte
+		released time.Time
+		err      error
+		source   = *src
+		format   = "csv"
+	)
+	if *file != "" {
+		source, format = *file, fileFormat(*file)
+		b, err = os.ReadFile(*file)
+		check(err)
+	} else {
+		client := &http.Client{Timeout: 30 * time.Second}
+		b, released, err = fetch(client, *src)
+		check(err)
+	}
+	if *date != "" {
+		released, err = time.Parse("2006-01-02", *date)
+		check(err)
+	} else if released.IsZero() {
+		released = time.Now()
+	}
+
+	parser := mlsdata.NewCSVParser(bytes.NewReader(b))
+	if *file != "" {
+		parser = mlsdata.NewFileParser(*file, bytes.NewReader(b))
+	}
+	parser.Report = &mlsdata.ParseReport{}
+	players, err := parser.All()
+	check(err)
+	if len(players) == 0 {
+		log.Fatalf("%s: no players in release", source)
+	}
+
+	name := filepath.Join(*dir, released.Format("2006_01_02")+"_data")
+	if _, err := os.Stat(name); err == nil && !*force {
+		log.Fatalf("%s exists; use -f to overwrite", name)
+	}
+	if *show {
+		check(preview(os.Stdout, format, players, parser.Report))
+		if !confirm(os.Stdout, os.Stdin, "write "+name+"?") {
+			return
+		}
+	}
+	buf := &bytes.Buffer{}
+	check(mlsdata.WriteData(buf, players))
+	check(os.WriteFile(name, buf.Bytes(), 0o644))
+	fmt.Printf("%s: %d players\n", name, len(players))
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// previewRows is the number of parsed players shown by preview
+const previewRows = 50
+
+// fileFormat returns the format NewFileParser reads name as
+func fileFormat(name string) string {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".xlsx":
+		return "xlsx"
+	case ".csv":
+		return "csv"
+	default:
+		return "tab separated text"
+	}
+}
+
+// preview writes the format, first parsed players, and count of each parse issue of a release
+func preview(w io.Writer, format string, players mlsdata.Players, report *mlsdata.ParseReport) error {
+	fmt.Fprintf(w, "format: %s\nplayers: %d\n\n", format, len(players))
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "line\tclub\tpos\tname\tbase salary\tcompensation\n")
+	for i, p := range players {
+		if i == previewRows {
+			fmt.Fprintf(t, "...\t%d more\n", len(players)-previewRows)
+			break
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\n", p.Line, p.Club, p.Pos.Label(), p.Name, p.BaseSalary, p.Compensation)
+	}
+	counts := report.Counts()
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return counts[reasons[i]] > counts[reasons[j]] })
+	fmt.Fprintln(t)
+	if len(reasons) == 0 {
+		fmt.Fprintln(t, "no parse issues")
+	}
+	for _, reason := range reasons {
+		fmt.Fprintf(t, "%d\t%s\n", counts[reason], reason)
+	}
+	return t.Flush()
+}
+
+// confirm asks question on w and returns true if the answer read from r is yes
+func confirm(w io.Writer, r io.Reader, question string) bool {
+	fmt.Fprintf(w, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(r).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
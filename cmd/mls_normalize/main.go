@@ -0,0 +1,139 @@
+// mls_normalize validates the data files in a directory, renames them to the canonical
+// YYYY_MM_DD_data pattern and optionally rewrites legacy space separated files in the
+// tab separated format.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+var (
+	canonicalName = regexp.MustCompile(`^\d{4}_\d{2}_\d{2}_data$`)
+	nameDate      = regexp.MustCompile(`(\d{4})[-_]?(\d{2})[-_]?(\d{2})`)
+	headerDate    = regexp.MustCompile(`([A-Z][a-z]+) (\d{1,2})(?:st|nd|rd|th)?, (\d{4})`)
+)
+
+// releaseDate returns the release date of a data file from its name or, failing that, its header
+func releaseDate(name string, b []byte) (time.Time, bool) {
+	if m := nameDate.FindStringSubmatch(name); m != nil {
+		if t, err := time.Parse("2006 01 02", strings.Join(m[1:], " ")); err == nil {
+			return t, true
+		}
+	}
+	if m := headerDate.FindSubmatch(b); m != nil {
+		if t, err := time.Parse("January 2 2006", fmt.Sprintf("%s %s %s", m[1], m[2], m[3])); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// rewrite returns players in the tab separated data file format
+func rewrite(players mlsdata.Players) []byte {
+	buf := &bytes.Buffer{}
+	check(mlsdata.WriteData(buf, players))
+	return buf.Bytes()
+}
+
+func main() {
+	var (
+		dir     = flag.String("dir", "cmd/mls_salaries/data", "data directory")
+		write   = flag.Bool("w", false, "rename and rewrite files instead of only reporting")
+		legacy  = flag.Bool("rewrite", false, "rewrite legacy space separated files in the tab separated format (with -w)")
+		failed  bool
+		targets = make(map[string]string)
+	)
+	log.SetFlags(0)
+	flag.Parse()
+
+	entries, err := os.ReadDir(*dir)
+	check(err)
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != "" {
+			continue
+		}
+		path := filepath.Join(*dir, name)
+		b, err := os.ReadFile(path)
+		check(err)
+
+		date, ok := releaseDate(name, b)
+		if !ok {
+			log.Printf("%s: no release date in file name or header", name)
+			failed = true
+			continue
+		}
+		target := date.Format("2006_01_02") + "_data"
+		if other, ok := targets[target]; ok {
+			log.Printf("%s: %s is also the canonical name of %s", name, target, other)
+			failed = true
+			continue
+		}
+		targets[target] = name
+
+		players, err := mlsdata.Parse(bytes.NewReader(b))
+		check(err)
+		var noClub, noPos int
+		var kept mlsdata.Players
+		for _, p := range players {
+			if p.Club == "" {
+				noClub++
+			}
+			if len(p.Pos) == 0 {
+				noPos++
+			}
+			kept = append(kept, p)
+		}
+		isLegacy := len(b) > 0 && b[0] != '\t'
+
+		var notes []string
+		if !canonicalName.MatchString(name) {
+			notes = append(notes, "rename to "+target)
+		}
+		if isLegacy {
+			notes = append(notes, "legacy format")
+		}
+		if noClub > 0 {
+			notes = append(notes, strconv.Itoa(noClub)+" without club")
+		}
+		if noPos > 0 {
+			notes = append(notes, strconv.Itoa(noPos)+" without position")
+		}
+		fmt.Println(strings.Join(append([]string{fmt.Sprintf("%s: %d players", name, len(kept))}, notes...), ", "))
+
+		if !*write {
+			continue
+		}
+		if isLegacy && *legacy {
+			check(os.WriteFile(path, rewrite(kept), 0o644))
+		}
+		if name != target {
+			if _, err := os.Stat(filepath.Join(*dir, target)); err == nil {
+				log.Printf("%s: not renamed, %s exists", name, target)
+				failed = true
+				continue
+			}
+			check(os.Rename(path, filepath.Join(*dir, target)))
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func check(err error) {
+	if err != nil {
+		log.Fatal(err)
+	}
+}
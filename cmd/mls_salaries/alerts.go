@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// alertTimeout bounds the post of -compare alerts to a webhook
+const alertTimeout = 5 * time.Second
+
+// AlertRule is a salary threshold checked between the releases of -compare. Each rule sets one
+// of MaxCompensation and MaxClubGrowth.
+type AlertRule struct {
+	// Name describes the rule in alerts; a description of the threshold is used if empty
+	Name string `json:"name,omitempty"`
+	// MaxCompensation alerts on players paid more than it in the later release
+	MaxCompensation mlsdata.Money `json:"max_compensation,omitempty"`
+	// NonDP limits MaxCompensation to players who aren't designated players
+	NonDP bool `json:"non_dp,omitempty"`
+	// MaxClubGrowth alerts on clubs whose total compensation grew by more than this percent
+	MaxClubGrowth float64 `json:"max_club_growth,omitempty"`
+}
+
+// String returns the name of the rule, or a description of its threshold
+func (r AlertRule) String() string {
+	switch {
+	case r.Name != "":
+		return r.Name
+	case r.MaxClubGrowth > 0:
+		return fmt.Sprintf("club total growth over %g%%", r.MaxClubGrowth)
+	case r.NonDP:
+		return "non-DP compensation over " + money(r.MaxCompensation)
+	default:
+		return "compensation over " + money(r.MaxCompensation)
+	}
+}
+
+// Alert is a club or player that triggered an AlertRule
+type Alert struct {
+	Rule string `json:"rule"`
+	Club string `json:"club"`
+	Name string `json:"name,omitempty"`
+	Text string `json:"text"`
+}
+
+// readAlertRules reads a json file of alert rules, shaped like {"alerts": [{"max_compensation":
+// 1500000, "non_dp": true}, {"max_club_growth": 20}]}
+func readAlertRules(name string) ([]AlertRule, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var v struct {
+		Alerts []AlertRule `json:"alerts"`
+	}
+	if err := json.NewDecoder(f).Decode(&v); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if len(v.Alerts) == 0 {
+		return nil, fmt.Errorf("%s: no alerts", name)
+	}
+	for i, r := range v.Alerts {
+		if (r.MaxCompensation > 0) == (r.MaxClubGrowth > 0) {
+			return nil, fmt.Errorf("%s: alert %d needs one of max_compensation and max_club_growth", name, i+1)
+		}
+	}
+	return v.Alerts, nil
+}
+
+// compareAlerts returns the alerts triggered by the players of two releases, by rule, with the
+// highest paid players and fastest growing clubs first
+func compareAlerts(from, to mlsdata.Players, rules []AlertRule) []Alert {
+	before, after := make(mlsdata.ClubTotals), make(mlsdata.ClubTotals)
+	for _, p := range from {
+		before[p.Club] += p.Compensation
+	}
+	for _, p := range to {
+		after[p.Club] += p.Compensation
+	}
+	growth := make(map[string]float64)
+	var grown []string
+	for club, total := range after {
+		if was := before[club]; was > 0 && club != "" && club != "MLS" {
+			growth[club] = (total - was).Float() / was.Float() * 100
+			grown = append(grown, club)
+		}
+	}
+	sort.Slice(grown, func(i, j int) bool {
+		if growth[grown[i]] != growth[grown[j]] {
+			return growth[grown[i]] > growth[grown[j]]
+		}
+		return grown[i] < grown[j]
+	})
+	sorted := append(mlsdata.Players(nil), to...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Compensation > sorted[j].Compensation })
+
+	var alerts []Alert
+	for _, r := range rules {
+		if r.MaxCompensation > 0 {
+			for _, p := range sorted {
+				if p.Compensation <= r.MaxCompensation {
+					break
+				}
+				if r.NonDP && p.Charge == mlsdata.ChargeDP {
+					continue
+				}
+				alerts = append(alerts, Alert{Rule: r.String(), Club: p.Club, Name: p.Name, Text: "paid " + money(p.Compensation)})
+			}
+			continue
+		}
+		for _, club := range grown {
+			if growth[club] <= r.MaxClubGrowth {
+				break
+			}
+			alerts = append(alerts, Alert{Rule: r.String(), Club: club,
+				Text: fmt.Sprintf("grew %.1f%% from %s to %s", growth[club], money(before[club]), money(after[club]))})
+		}
+	}
+	return alerts
+}
+
+// writeAlerts writes the alerts section of the compare report
+func writeAlerts(w io.Writer, alerts []Alert) {
+	fmt.Fprintf(w, "\nalerts\n")
+	if len(alerts) == 0 {
+		fmt.Fprintln(w, "none triggered")
+	}
+	for _, a := range alerts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Rule, a.Club, a.Name, a.Text)
+	}
+}
+
+// postAlerts posts the alerts triggered between the data files from and to as json to url.
+// Nothing is posted if no alerts were triggered.
+func postAlerts(url, from, to string, alerts []Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+	b, err := json.Marshal(struct {
+		From   string  `json:"from"`
+		To     string  `json:"to"`
+		Alerts []Alert `json:"alerts"`
+	}{from, to, alerts})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: alertTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return nil
+}
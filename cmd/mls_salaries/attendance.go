@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// correlation returns the Pearson correlation coefficient of xs and ys, or NaN if it is undefined
+func correlation(xs, ys []float64) float64 {
+	n := float64(len(xs))
+	if len(xs) < 2 || len(xs) != len(ys) {
+		return math.NaN()
+	}
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var cov, varX, varY float64
+	for i := range xs {
+		dx, dy := xs[i]-meanX, ys[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return math.NaN()
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// attendanceReport writes each club's payroll and average attendance using the attendance file name,
+// followed by the correlation between them
+func attendanceReport(w io.Writer, name string, totals mlsdata.ClubTotals) error {
+	attendance, err := readClubValues(name)
+	if err != nil {
+		return err
+	}
+	var clubs []string
+	for club := range attendance {
+		clubs = append(clubs, club)
+	}
+	sort.Slice(clubs, func(i, j int) bool { return totals[clubs[i]] > totals[clubs[j]] })
+
+	var payrolls, crowds []float64
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "\tclub\tpayroll\tattendance\n")
+	for i, club := range clubs {
+		payrolls = append(payrolls, totals[club].Float())
+		crowds = append(crowds, attendance[club])
+		fmt.Fprintf(t, "%d\t%s\t%s\t%.0f\n", i+1, club, money(totals[club]), attendance[club])
+	}
+	fmt.Fprintf(t, "\ncorrelation: %.3f\n", correlation(payrolls, crowds))
+	return t.Flush()
+}
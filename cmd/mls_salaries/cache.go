@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// cacheVersion is part of every cache key; change it when report output changes
+const cacheVersion = "6"
+
+// cacheKey returns a key for the query args over every data file, the roster rules, which -rules
+// may have replaced, the inputs, data files the report reads that may be on disk rather than
+// embedded, and the files, like -overrides and -loans, the query names
+func cacheKey(args []string, inputs, files []string) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, cacheVersion+"\x00")
+	for _, arg := range args {
+		io.WriteString(h, arg+"\x00")
+	}
+	names, err := dataFiles()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range append(names, inputs...) {
+		f, err := openData(name)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(h, name+"\x00")
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, name := range files {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return "", err
+		}
+		io.WriteString(h, name+"\x00")
+		h.Write(b)
+	}
+	if err := json.NewEncoder(h).Encode(mlsdata.SeasonRules); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// cachedReport writes the cached output of report for key to w, running report and caching its
+// output if there is none. The cache is in the user cache directory; if that can't be used the
+// report is run uncached.
+func cachedReport(w io.Writer, key string, report func(io.Writer) error) error {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return report(w)
+	}
+	name := filepath.Join(dir, "mls_salaries", key)
+	if b, err := os.ReadFile(name); err == nil {
+		_, err = w.Write(b)
+		return err
+	}
+	buf := &bytes.Buffer{}
+	if err := report(buf); err != nil {
+		return err
+	}
+	// a failed write only costs the next run its cache hit
+	_ = writeCache(name, buf.Bytes())
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// writeCache writes b to the cache file name through a temporary file, so readers never see
+// a partial entry
+func writeCache(name string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
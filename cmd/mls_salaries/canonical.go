@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+//go:generate go run . -canonical canonical
+
+// canonicalCSV returns the players of a release as csv sorted by club, name, and compensation,
+// so the files of successive releases diff cleanly
+func canonicalCSV(players mlsdata.Players) ([]byte, error) {
+	sorted := append(mlsdata.Players(nil), players...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Club != b.Club {
+			return a.Club < b.Club
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Compensation < b.Compensation
+	})
+	b := &bytes.Buffer{}
+	if err := writeCSV(b, sorted, false); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// canonicalName returns the canonical csv file name of a data file
+func canonicalName(data string) string {
+	return strings.TrimSuffix(data, path.Ext(data)) + ".csv"
+}
+
+// writeCanonical writes the canonical csv of every data file to dir
+func writeCanonical(dir string) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	for _, r := range releases {
+		b, err := canonicalCSV(r.Players)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dir, canonicalName(r.Data)), b, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// canonicalReport compares the canonical csv files in dir with the ones regenerated from the
+// data files, writing the rows missing from dir with a "+" and the stale rows with a "-".
+// It returns an error if any file differs.
+func canonicalReport(w io.Writer, dir string) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	stale := 0
+	for _, r := range releases {
+		b, err := canonicalCSV(r.Players)
+		if err != nil {
+			return err
+		}
+		name := canonicalName(r.Data)
+		old, err := os.ReadFile(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			stale++
+			fmt.Fprintf(w, "%s: missing\n", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(old, b) {
+			continue
+		}
+		stale++
+		fmt.Fprintf(w, "%s:\n", name)
+		added, removed := diffLines(string(old), string(b))
+		for _, line := range removed {
+			fmt.Fprintf(w, "- %s\n", line)
+		}
+		for _, line := range added {
+			fmt.Fprintf(w, "+ %s\n", line)
+		}
+	}
+	if stale > 0 {
+		return fmt.Errorf("%d canonical csv files in %s are out of date; regenerate them with go generate", stale, dir)
+	}
+	return nil
+}
+
+// diffLines returns the lines of b that aren't in a, and the lines of a that aren't in b
+func diffLines(a, b string) (added, removed []string) {
+	count := make(map[string]int)
+	for _, line := range strings.Split(a, "\n") {
+		count[line]++
+	}
+	for _, line := range strings.Split(b, "\n") {
+		if count[line] > 0 {
+			count[line]--
+		} else {
+			added = append(added, line)
+		}
+	}
+	for _, line := range strings.Split(a, "\n") {
+		if count[line] > 0 {
+			count[line]--
+			removed = append(removed, line)
+		}
+	}
+	return added, removed
+}
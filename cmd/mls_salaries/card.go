@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// percentile returns the percentage of players paid no more than comp
+func percentile(players mlsdata.Players, comp mlsdata.Money) float64 {
+	if len(players) == 0 {
+		return 0
+	}
+	n := 0
+	for _, p := range players {
+		if p.Compensation <= comp {
+			n++
+		}
+	}
+	return float64(n) / float64(len(players)) * 100
+}
+
+// cardSVG is a social media sized (1200x630) salary card
+const cardSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="1200" height="630" viewBox="0 0 1200 630">
+<rect width="1200" height="630" fill="#101820"/>
+<text x="80" y="170" font-family="sans-serif" font-size="72" font-weight="bold" fill="#ffffff">%s</text>
+<text x="80" y="250" font-family="sans-serif" font-size="40" fill="#9ba7b4">%s · %s</text>
+<text x="80" y="400" font-family="sans-serif" font-size="96" font-weight="bold" fill="#f2c14e">$%s</text>
+<text x="80" y="470" font-family="sans-serif" font-size="36" fill="#ffffff">guaranteed compensation · percentile %.0f</text>
+<text x="80" y="570" font-family="sans-serif" font-size="28" fill="#9ba7b4">MLS Players Association salary release %s</text>
+</svg>
+`
+
+// writeCard writes an SVG salary card of the first player matching name in players
+func writeCard(w io.Writer, data, name string, players mlsdata.Players) error {
+	match := mlsdata.Players{{Name: name}}
+	for _, p := range players {
+		if !match.HasVal(p.Name) {
+			continue
+		}
+		_, err := fmt.Fprintf(w, cardSVG, html.EscapeString(p.Name), html.EscapeString(p.Club), html.EscapeString(p.Pos.Label()),
+			p.Compensation, percentile(players, p.Compensation), html.EscapeString(data))
+		return err
+	}
+	return fmt.Errorf("no player matching %q in %s", name, data)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// chargeKinds are the budget charge classifications of the charge trend, with the character of
+// each in its stacked bars
+var chargeKinds = []struct {
+	charge string
+	mark   string
+}{{mlsdata.ChargeDP, "D"}, {mlsdata.ChargeTAM, "T"}, {mlsdata.ChargeBudget, "."}}
+
+// chargeTrendWidth is the width of the stacked bars of the charge trend
+const chargeTrendWidth = 50
+
+// chargeTrendReport writes the share of league payroll paid to designated players, TAM players,
+// and players charged at their compensation in every data file, as a table and stacked bars.
+// Players paid over threshold, if set, are designated players.
+func chargeTrendReport(w io.Writer, threshold mlsdata.Money) error {
+	releases, err := loadReleases(threshold)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(t, "data\ttotal")
+	for _, k := range chargeKinds {
+		fmt.Fprintf(t, "\t%s\t%s %%", k.charge, k.charge)
+	}
+	fmt.Fprintln(t)
+	for _, r := range releases {
+		season := mlsdata.DataSeason(r.Data)
+		spend := make(map[string]mlsdata.Money)
+		var total mlsdata.Money
+		for _, p := range r.Players {
+			if mlsdata.InLeague(p.Club, season) {
+				spend[p.Charge] += p.Compensation
+				total += p.Compensation
+			}
+		}
+		fmt.Fprintf(t, "%s\t%s", r.Data, money(total))
+		var bar strings.Builder
+		for _, k := range chargeKinds {
+			share := 0.0
+			if total > 0 {
+				share = spend[k.charge].Float() / total.Float() * 100
+			}
+			fmt.Fprintf(t, "\t%s\t%.1f%%", money(spend[k.charge]), share)
+			bar.WriteString(strings.Repeat(k.mark, int(math.Round(share/100*chargeTrendWidth))))
+		}
+		fmt.Fprintf(t, "\t%s\n", bar.String())
+	}
+	fmt.Fprintf(t, "\nD: %s, T: %s, .: %s\n", mlsdata.ChargeDP, mlsdata.ChargeTAM, mlsdata.ChargeBudget)
+	return t.Flush()
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// chart dimensions, in pixels
+const (
+	chartWidth    = 800
+	chartLabels   = 140 // width of the label column of bar charts
+	chartBarPitch = 22
+	chartMargin   = 40
+)
+
+// distributionBuckets are the upper bounds of the compensation histogram buckets, each
+// double the last; the final bucket is open ended
+var distributionBuckets = []mlsdata.Money{100_000 * mlsdata.Dollar, 200_000 * mlsdata.Dollar, 400_000 * mlsdata.Dollar,
+	800_000 * mlsdata.Dollar, 1_600_000 * mlsdata.Dollar, 3_200_000 * mlsdata.Dollar}
+
+// svgBarChart writes a horizontal bar chart of values, one bar per label, with each value
+// written by format
+func svgBarChart(w io.Writer, title string, labels []string, values []float64, format func(float64) string) error {
+	var max float64
+	for _, v := range values {
+		max = math.Max(max, v)
+	}
+	height := chartMargin*2 + chartBarPitch*len(labels)
+	barSpace := float64(chartWidth - chartLabels - 120)
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, height, chartWidth, height)
+	fmt.Fprintf(w, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", chartWidth, height)
+	fmt.Fprintf(w, `<text x="10" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", html.EscapeString(title))
+	for i, label := range labels {
+		y := chartMargin + i*chartBarPitch
+		width := 0.0
+		if max > 0 {
+			width = values[i] / max * barSpace
+		}
+		fmt.Fprintf(w, `<text x="10" y="%d">%s</text>`+"\n", y+15, html.EscapeString(label))
+		fmt.Fprintf(w, `<rect x="%d" y="%d" width="%.1f" height="%d" fill="#2b6b9f"/>`+"\n", chartLabels, y+3, width, chartBarPitch-6)
+		fmt.Fprintf(w, `<text x="%.1f" y="%d">%s</text>`+"\n", float64(chartLabels)+width+6, y+15, format(values[i]))
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+// svgLineChart writes a line chart of each series over labels, with a legend of the series names
+func svgLineChart(w io.Writer, title string, labels []string, names []string, series [][]float64) error {
+	const height, left, right = 420, 110, 20
+	colors := []string{"#2b6b9f", "#b83232", "#3a9c5c"}
+	var max float64
+	for _, s := range series {
+		for _, v := range s {
+			max = math.Max(max, v)
+		}
+	}
+	plotW, plotH := float64(chartWidth-left-right), float64(height-2*chartMargin-40)
+	x := func(i int) float64 {
+		if len(labels) < 2 {
+			return left
+		}
+		return left + float64(i)*plotW/float64(len(labels)-1)
+	}
+	y := func(v float64) float64 {
+		if max == 0 {
+			return chartMargin + plotH
+		}
+		return chartMargin + plotH - v/max*plotH
+	}
+	fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		chartWidth, height, chartWidth, height)
+	fmt.Fprintf(w, `<rect width="%d" height="%d" fill="#ffffff"/>`+"\n", chartWidth, height)
+	fmt.Fprintf(w, `<text x="10" y="24" font-size="16" font-weight="bold">%s</text>`+"\n", html.EscapeString(title))
+	for _, frac := range []float64{0, 0.5, 1} {
+		fmt.Fprintf(w, `<line x1="%d" y1="%.1f" x2="%d" y2="%.1f" stroke="#dddddd"/>`+"\n", left, y(max*frac), chartWidth-right, y(max*frac))
+		fmt.Fprintf(w, `<text x="10" y="%.1f">%s</text>`+"\n", y(max*frac)+4, money(mlsdata.Dollars(max*frac)))
+	}
+	for i, label := range labels {
+		fmt.Fprintf(w, `<text x="%.1f" y="%.1f" text-anchor="end" transform="rotate(-45 %.1f %.1f)">%s</text>`+"\n",
+			x(i), chartMargin+plotH+16, x(i), chartMargin+plotH+16, html.EscapeString(label))
+	}
+	for n, s := range series {
+		points := make([]string, len(s))
+		for i, v := range s {
+			points[i] = fmt.Sprintf("%.1f,%.1f", x(i), y(v))
+		}
+		color := colors[n%len(colors)]
+		fmt.Fprintf(w, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`+"\n", strings.Join(points, " "), color)
+		fmt.Fprintf(w, `<text x="%d" y="%d" fill="%s">%s</text>`+"\n", chartWidth-200, 24+16*n, color, html.EscapeString(names[n]))
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+// distribution returns the bucket labels and number of players in each distributionBuckets bucket
+func distribution(players mlsdata.Players) ([]string, []float64) {
+	labels := make([]string, len(distributionBuckets)+1)
+	counts := make([]float64, len(labels))
+	var lo mlsdata.Money
+	for i, hi := range distributionBuckets {
+		labels[i] = fmt.Sprintf("%s–%s", mlsdata.FormatUnit(lo, "k"), mlsdata.FormatUnit(hi, "k"))
+		lo = hi
+	}
+	labels[len(labels)-1] = mlsdata.FormatUnit(lo, "k") + "+"
+	for _, p := range players {
+		i := sort.Search(len(distributionBuckets), func(i int) bool { return distributionBuckets[i] >= p.Compensation })
+		counts[i]++
+	}
+	return labels, counts
+}
+
+// writeCharts writes SVG charts of the club totals and compensation distribution of the data file
+// and of league payroll over every release up to its season to dir
+func writeCharts(dir, data string, players mlsdata.Players, totals mlsdata.ClubTotals) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	write := func(name string, render func(io.Writer) error) error {
+		buf := &bytes.Buffer{}
+		if err := render(buf); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644)
+	}
+
+	var clubs []string
+	var payrolls []float64
+	for _, kv := range totals.Sort() {
+		clubs = append(clubs, kv.Key)
+		payrolls = append(payrolls, kv.Value.Float())
+	}
+	if err := write("club_totals.svg", func(w io.Writer) error {
+		return svgBarChart(w, "Club payroll, "+data, clubs, payrolls, func(v float64) string { return money(mlsdata.Dollars(v)) })
+	}); err != nil {
+		return err
+	}
+
+	labels, counts := distribution(players)
+	if err := write("distribution.svg", func(w io.Writer) error {
+		return svgBarChart(w, "Players by guaranteed compensation, "+data, labels, counts,
+			func(v float64) string { return fmt.Sprintf("%.0f", v) })
+	}); err != nil {
+		return err
+	}
+
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	season := mlsdata.ParseSeason(data)
+	var names []string
+	var average, top []float64
+	for _, r := range releases {
+		rs := mlsdata.ParseSeason(r.Data)
+		if season.Before(rs) {
+			continue
+		}
+		byClub := make(mlsdata.ClubTotals)
+		for _, p := range r.Players {
+			if p.Club != "" && p.Club != "MLS" && mlsdata.InLeague(p.Club, rs.Year) {
+				byClub[p.Club] += p.Compensation
+			}
+		}
+		var total, max mlsdata.Money
+		for _, v := range byClub {
+			total += v
+			if v > max {
+				max = v
+			}
+		}
+		names = append(names, rs.String())
+		average = append(average, total.Float()/math.Max(float64(len(byClub)), 1))
+		top = append(top, max.Float())
+	}
+	return write("trends.svg", func(w io.Writer) error {
+		return svgLineChart(w, "Club payroll by release", names,
+			[]string{"average club", "highest club"}, [][]float64{average, top})
+	})
+}
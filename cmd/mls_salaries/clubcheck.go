@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// clubSeasons returns the seasons of a club in the league, like "2005-2014" or "2023-"
+func clubSeasons(s mlsdata.ClubSeason) string {
+	if s.Last == 0 {
+		return fmt.Sprintf("%d-", s.First)
+	}
+	return fmt.Sprintf("%d-%d", s.First, s.Last)
+}
+
+// clubsReport writes the players of every data file listed at a club that wasn't in the league
+// that season, usually a sign of a misclassified club alias, and returns an error if there are any.
+// Expansion clubs may list players the season before their first.
+func clubsReport(w io.Writer) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	n := 0
+	for _, r := range releases {
+		season := mlsdata.DataSeason(r.Data)
+		for _, p := range r.Players {
+			if p.Club == "" || mlsdata.InRelease(p.Club, season) {
+				continue
+			}
+			n++
+			fmt.Fprintf(t, "%s:%d\t%s\t%s\tin the league %s\n", p.File, p.Line, p.Club, p.Name, clubSeasons(mlsdata.ClubSeasons[p.Club]))
+		}
+	}
+	if n == 0 {
+		fmt.Fprintln(t, "No players at clubs outside the league found")
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	if n > 0 {
+		return fmt.Errorf("%d players listed at clubs not in the league that season", n)
+	}
+	return nil
+}
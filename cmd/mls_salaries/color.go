@@ -0,0 +1,86 @@
+package main
+
+import (
+	"os"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// ANSI escape codes. The codes used inside a table have the same length so tabwriter
+// columns stay aligned as long as every cell in a column uses one of them.
+const (
+	ansiReset     = "\x1b[0m"
+	ansiDefault   = "\x1b[39m"
+	ansiHighlight = "\x1b[33m"
+	ansiBold      = "\x1b[01m"
+	ansiNormal    = "\x1b[22m"
+	ansiRaise     = "\x1b[32m"
+	ansiCut       = "\x1b[31m"
+)
+
+// clubColors are the ANSI foreground colors used for club abbreviations
+var clubColors = []string{
+	"\x1b[31m", "\x1b[32m", "\x1b[34m", "\x1b[35m", "\x1b[36m",
+	"\x1b[91m", "\x1b[92m", "\x1b[94m", "\x1b[95m", "\x1b[96m",
+}
+
+// isTerminal returns true if f is a terminal
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+// palette adds ANSI colors to table cells. It returns cells unchanged when disabled.
+type palette struct {
+	enabled   bool
+	highlight mlsdata.Clubs
+}
+
+// row returns the codes starting and ending a table row belonging to club
+func (p palette) row(club string) (start, end string) {
+	switch {
+	case !p.enabled:
+		return "", ""
+	case p.highlight != nil && p.highlight.HasVal(club):
+		return ansiHighlight, ansiReset
+	default:
+		return ansiDefault, ansiReset
+	}
+}
+
+// club returns the club abbreviation in its color, restoring the row color after it
+func (p palette) club(club string) string {
+	if !p.enabled {
+		return club
+	}
+	var h uint32
+	for _, r := range club {
+		h = h*31 + uint32(r)
+	}
+	start, _ := p.row(club)
+	return clubColors[h%uint32(len(clubColors))] + club + start
+}
+
+// change returns the text of a compensation change, green for a raise and red for a cut
+func (p palette) change(text string, delta mlsdata.Money) string {
+	switch {
+	case !p.enabled || delta == 0:
+		return text
+	case delta > 0:
+		return ansiRaise + text + ansiReset
+	default:
+		return ansiCut + text + ansiReset
+	}
+}
+
+// name returns a player name, bolded if dp is true
+func (p palette) name(name string, dp bool) string {
+	switch {
+	case !p.enabled:
+		return name
+	case dp:
+		return ansiBold + name + ansiNormal
+	default:
+		return ansiNormal + name + ansiNormal
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// salaryCols are the salary columns -col and -sort-by accept
+var salaryCols = []string{"comp", "base"}
+
+// salary returns the salary column col of p
+func salary(p mlsdata.Player, col string) mlsdata.Money {
+	if col == "base" {
+		return p.BaseSalary
+	}
+	return p.Compensation
+}
+
+// checkCol returns an error if col is not one of salaryCols
+func checkCol(col string) error {
+	for _, valid := range salaryCols {
+		if col == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown salary column %q, valid values: %s", col, strings.Join(salaryCols, ", "))
+}
+
+// parseCols splits the -col value into its salary columns
+func parseCols(s string) ([]string, error) {
+	var cols []string
+	for _, col := range strings.Split(s, ",") {
+		col = strings.TrimSpace(col)
+		if err := checkCol(col); err != nil {
+			return nil, err
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
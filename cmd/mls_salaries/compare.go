@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// Change kinds, in report order
+const (
+	changeRaise = iota
+	changeCut
+	changeNew
+	changeGone
+)
+
+var changeNames = []string{"raise", "cut", "new", "gone"}
+
+// SalaryChange is the difference in a player's compensation between two releases
+type SalaryChange struct {
+	Kind  int
+	Club  string
+	Name  string
+	From  mlsdata.Money
+	To    mlsdata.Money
+	Delta mlsdata.Money
+}
+
+// parseCompare splits the -compare value into its two data files, earliest first
+func parseCompare(s string) (string, string, error) {
+	from, to, ok := strings.Cut(s, ",")
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if !ok || from == "" || to == "" || strings.Contains(to, ",") {
+		return "", "", fmt.Errorf("-compare takes two comma separated data files, got %q", s)
+	}
+	if mlsdata.ParseSeason(to).Before(mlsdata.ParseSeason(from)) {
+		return "", "", fmt.Errorf("-compare data files must be in release order, %s was released before %s", to, from)
+	}
+	return from, to, nil
+}
+
+// salaryChanges returns the raises, cuts, new signings, and departures between the players of two
+// releases. Players are matched by name key and unchanged players are left out.
+func salaryChanges(from, to mlsdata.Players) []SalaryChange {
+	before := make(map[string]mlsdata.Player, len(from))
+	for _, p := range from {
+		before[mlsdata.NameKey(p.Name)] = p
+	}
+	var changes []SalaryChange
+	seen := make(map[string]bool, len(to))
+	for _, p := range to {
+		key := mlsdata.NameKey(p.Name)
+		seen[key] = true
+		prev, ok := before[key]
+		c := SalaryChange{Club: p.Club, Name: p.Name, From: prev.Compensation, To: p.Compensation}
+		c.Delta = c.To - c.From
+		switch {
+		case !ok:
+			c.Kind = changeNew
+		case c.Delta > 0:
+			c.Kind = changeRaise
+		case c.Delta < 0:
+			c.Kind = changeCut
+		default:
+			continue
+		}
+		changes = append(changes, c)
+	}
+	for _, p := range from {
+		if key := mlsdata.NameKey(p.Name); !seen[key] {
+			seen[key] = true
+			changes = append(changes, SalaryChange{Kind: changeGone, Club: p.Club, Name: p.Name, From: p.Compensation, Delta: -p.Compensation})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Delta.Abs() > changes[j].Delta.Abs()
+	})
+	return changes
+}
+
+// compareReleases returns the players matching keep in the data files from and to, classified
+// with threshold and with compensation adjusted for inflation if adjust is true
+func compareReleases(from, to string, keep func(mlsdata.Player) bool, threshold mlsdata.Money, adjust bool) ([2]mlsdata.Players, error) {
+	var releases [2]mlsdata.Players
+	for i, name := range []string{from, to} {
+		players, err := loadData(name, threshold)
+		if err != nil {
+			return releases, err
+		}
+		for _, p := range players {
+			if !keep(p) {
+				continue
+			}
+			if adjust {
+				p.Compensation = mlsdata.AdjustRelease(p.Compensation, name)
+			}
+			releases[i] = append(releases[i], p)
+		}
+	}
+	return releases, nil
+}
+
+// compareReport writes the compensation changes of the players matching keep between the data
+// files from and to, classified with threshold, in the dollars of the latest CPI season if adjust
+// is true, with raises and cuts colored by paint, followed by the alerts triggered by rules
+func compareReport(w io.Writer, from, to string, keep func(mlsdata.Player) bool, threshold mlsdata.Money, adjust bool, paint palette, rules []AlertRule) error {
+	releases, err := compareReleases(from, to, keep, threshold, adjust)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	counts := make([]int, len(changeNames))
+	for _, c := range salaryChanges(releases[0], releases[1]) {
+		counts[c.Kind]++
+		sign := "+"
+		if c.Delta < 0 {
+			sign = "-"
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%s\t%s\n", changeNames[c.Kind], c.Club, c.Name,
+			money(c.From), money(c.To), paint.change(sign+money(c.Delta.Abs()), c.Delta))
+	}
+	fmt.Fprintf(t, "\n%d raises, %d cuts, %d new, %d gone\n",
+		counts[changeRaise], counts[changeCut], counts[changeNew], counts[changeGone])
+	if adjust {
+		fmt.Fprintf(t, "in %d dollars\n", mlsdata.CPISeason())
+	}
+	if len(rules) > 0 {
+		writeAlerts(t, compareAlerts(releases[0], releases[1], rules))
+	}
+	return t.Flush()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// Concentration is the share of a release's total payroll paid to its top earners
+type Concentration struct {
+	Data    string
+	Players int
+	Total   mlsdata.Money
+	Top1    float64
+	Top5    float64
+}
+
+// concentration returns the payroll share of the top 1% and top 5% of players, leaving out the
+// MLS pool and clubs not yet in the league
+func concentration(data string, players mlsdata.Players) Concentration {
+	c := Concentration{Data: data}
+	season := mlsdata.DataSeason(data)
+	var comps []mlsdata.Money
+	for _, p := range players {
+		if p.Club == "MLS" || !mlsdata.InLeague(p.Club, season) {
+			continue
+		}
+		comps = append(comps, p.Compensation)
+		c.Total += p.Compensation
+	}
+	c.Players = len(comps)
+	if c.Total == 0 {
+		return c
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i] > comps[j] })
+	topShare := func(pct float64) float64 {
+		n := int(math.Ceil(float64(len(comps)) * pct / 100))
+		var sum mlsdata.Money
+		for _, v := range comps[:n] {
+			sum += v
+		}
+		return sum.Float() / c.Total.Float() * 100
+	}
+	c.Top1 = topShare(1)
+	c.Top5 = topShare(5)
+	return c
+}
+
+// concentrationReport writes the payroll concentration of every embedded data file as a table and bar chart
+func concentrationReport(w io.Writer) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	var all []Concentration
+	for _, r := range releases {
+		all = append(all, concentration(r.Data, r.Players))
+	}
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "data\tplayers\ttotal\ttop 1%%\ttop 5%%\n")
+	for _, c := range all {
+		fmt.Fprintf(t, "%s\t%d\t%s\t%.1f%%\t%.1f%%\n", c.Data, c.Players, money(c.Total), c.Top1, c.Top5)
+	}
+	fmt.Fprintf(t, "\n")
+	for _, c := range all {
+		fmt.Fprintf(t, "%s\t%s\t%s\n", c.Data, strings.Repeat("#", int(math.Round(c.Top1))), strings.Repeat("#", int(math.Round(c.Top5))))
+	}
+	return t.Flush()
+}
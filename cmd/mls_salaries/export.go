@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// writeCSV writes players as csv with a header row. The data file and line of each player
+// are included if provenance is true.
+func writeCSV(w io.Writer, players mlsdata.Players, provenance bool) error {
+	cw := csv.NewWriter(w)
+	header := []string{"club", "name", "pos", "base_salary", "compensation"}
+	if provenance {
+		header = append(header, "file", "line", "overridden", "club_inferred")
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, p := range players {
+		record := []string{
+			p.Club,
+			p.Name,
+			p.Pos.Label(),
+			strconv.FormatFloat(p.BaseSalary.Float(), 'f', 2, 64),
+			strconv.FormatFloat(p.Compensation.Float(), 'f', 2, 64),
+		}
+		if provenance {
+			record = append(record, p.File, strconv.Itoa(p.Line), strconv.FormatBool(p.Overridden), strconv.FormatBool(p.ClubInferred))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// markdownCell escapes the pipes and newlines of s for a markdown table cell
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
+
+// writeMarkdown writes players as a GitHub flavored markdown table with the salary columns cols,
+// for pasting into forums
+func writeMarkdown(w io.Writer, players mlsdata.Players, cols []string) error {
+	header, align := "| # | club | pos | name |", "|--:|---|---|---|"
+	for _, col := range cols {
+		header += " " + col + " |"
+		align += "--:|"
+	}
+	fmt.Fprintf(w, "%s\n%s\n", header, align)
+	for i, p := range players {
+		row := fmt.Sprintf("| %d | %s | %s | %s |", i+1, markdownCell(p.Club), markdownCell(p.Pos.Label()), markdownCell(p.Name))
+		for _, col := range cols {
+			row += " " + money(salary(p, col)) + " |"
+		}
+		if _, err := fmt.Fprintln(w, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeJSON writes players as an indented json array. The data file and line of each player
+// are included if provenance is true.
+func writeJSON(w io.Writer, players mlsdata.Players, provenance bool) error {
+	if !provenance {
+		stripped := make(mlsdata.Players, len(players))
+		for i, p := range players {
+			p.File, p.Line, p.Overridden, p.ClubInferred = "", 0, false, false
+			stripped[i] = p
+		}
+		players = stripped
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(players)
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// ManifestFile describes one file of an export archive
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Rows   int    `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// Manifest describes an export archive
+type Manifest struct {
+	Version string         `json:"version"`
+	Created time.Time      `json:"created"`
+	Files   []ManifestFile `json:"files"`
+}
+
+// Constants are the league values used to interpret the data
+type Constants struct {
+	Rules       []mlsdata.Rules               `json:"rules"`
+	Clubs       []mlsdata.Club                `json:"clubs"`
+	ClubSeasons map[string]mlsdata.ClubSeason `json:"club_seasons"`
+	Positions   mlsdata.Pos                   `json:"positions"`
+}
+
+// exportAll writes a zip archive to name holding a csv file per data file, a combined parquet
+// file with provenance columns, the league constants, and a manifest
+func exportAll(name string) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	manifest := Manifest{Created: time.Now().UTC()}
+	if len(releases) > 0 {
+		manifest.Version = strings.TrimSuffix(releases[len(releases)-1].Data, "_data")
+	}
+
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	create := func(name string) (io.Writer, error) {
+		return zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: manifest.Created})
+	}
+	add := func(name string, rows int, b []byte) error {
+		w, err := create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		sum := sha256.Sum256(b)
+		manifest.Files = append(manifest.Files, ManifestFile{Name: name, Rows: rows, SHA256: hex.EncodeToString(sum[:])})
+		return nil
+	}
+
+	var all mlsdata.Players
+	for _, r := range releases {
+		b := &bytes.Buffer{}
+		if err := writeCSV(b, r.Players, false); err != nil {
+			return err
+		}
+		if err := add(canonicalName(r.Data), len(r.Players), b.Bytes()); err != nil {
+			return err
+		}
+		all = append(all, r.Players...)
+	}
+	b := &bytes.Buffer{}
+	if err := writeParquet(b, all); err != nil {
+		return err
+	}
+	if err := add("all.parquet", len(all), b.Bytes()); err != nil {
+		return err
+	}
+
+	constants, err := json.MarshalIndent(Constants{
+		Rules:       mlsdata.SeasonRules,
+		Clubs:       mlsdata.Registry,
+		ClubSeasons: mlsdata.ClubSeasons,
+		Positions:   mlsdata.AllPos,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := add("constants.json", 0, constants); err != nil {
+		return err
+	}
+
+	m, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	w, err := create("manifest.json")
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(m); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(name, buf.Bytes(), 0o644)
+}
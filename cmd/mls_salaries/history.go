@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// historyReport writes the compensation of every player matching query in each data file,
+// with the change since their previous release, their club moves, and position group changes
+func historyReport(w io.Writer, query string, adjust bool) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	// match by name key, so releases spelling the name differently are included
+	matched := make(map[string]bool)
+	for _, r := range releases {
+		for _, p := range r.Players {
+			if mlsdata.MatchName(p.Name, query) {
+				matched[mlsdata.NameKey(p.Name)] = true
+			}
+		}
+	}
+	// players in the order they first appear
+	var keys []string
+	rows := make(map[string][]mlsdata.Player)
+	for _, r := range releases {
+		for _, p := range r.Players {
+			key := mlsdata.NameKey(p.Name)
+			if !matched[key] {
+				continue
+			}
+			if _, ok := rows[key]; !ok {
+				keys = append(keys, key)
+			}
+			if adjust {
+				p.Compensation = mlsdata.AdjustRelease(p.Compensation, r.Data)
+			}
+			rows[key] = append(rows[key], p)
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Fprintln(w, "No matches found")
+		return nil
+	}
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for i, key := range keys {
+		history := rows[key]
+		if i > 0 {
+			fmt.Fprintln(t)
+		}
+		// the latest release has the current spelling of the name
+		fmt.Fprintln(t, history[len(history)-1].Name)
+		for j, p := range history {
+			line := fmt.Sprintf("  %s\t%s\t%s\t%s", mlsdata.ParseSeason(p.File), p.Club, p.Pos.Label(), money(p.Compensation))
+			if j > 0 {
+				prev := history[j-1]
+				if delta := p.Compensation - prev.Compensation; delta >= 0 {
+					line += "\t+" + money(delta)
+				} else {
+					line += "\t-" + money(-delta)
+				}
+				if p.Club != prev.Club {
+					line += "\tfrom " + prev.Club
+				}
+				// groups, as releases list positions as letters or as descriptive labels
+				if was, is := prev.Pos.Group(), p.Pos.Group(); was != "" && is != "" && was != is {
+					line += "\tposition " + was + " to " + is
+				}
+			}
+			fmt.Fprintln(t, line)
+		}
+	}
+	if adjust {
+		fmt.Fprintf(t, "\nin %d dollars\n", mlsdata.CPISeason())
+	}
+	return t.Flush()
+}
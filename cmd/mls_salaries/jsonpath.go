@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// pathStep is one step of an -output-path: an object key, an array index, or every array element
+type pathStep struct {
+	key   string
+	index int
+	all   bool
+}
+
+// parseOutputPath parses a path like "players[].compensation" or "players[0].pos[]". The
+// leading "players" names the array of players and may be left out, as in "[].name".
+func parseOutputPath(s string) ([]pathStep, error) {
+	rest := strings.TrimPrefix(strings.TrimPrefix(s, "."), "players")
+	var steps []pathStep
+	for _, part := range strings.Split(rest, ".") {
+		key := part
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key = part[:i]
+		}
+		if key != "" {
+			steps = append(steps, pathStep{key: key, index: -1})
+		}
+		for part = part[len(key):]; part != ""; {
+			end := strings.IndexByte(part, ']')
+			if part[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("invalid output path %q", s)
+			}
+			if inner := part[1:end]; inner == "" {
+				steps = append(steps, pathStep{all: true})
+			} else {
+				i, err := strconv.Atoi(inner)
+				if err != nil || i < 0 {
+					return nil, fmt.Errorf("invalid index %q in output path %q", inner, s)
+				}
+				steps = append(steps, pathStep{index: i})
+			}
+			part = part[end+1:]
+		}
+	}
+	return steps, nil
+}
+
+// normalizeKey folds a key so "BaseSalary", "baseSalary", and "base_salary" are the same
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.ReplaceAll(key, "_", ""))
+}
+
+// selectPath returns the values at steps in v. Steps after an every element step apply to each
+// element, so a path can select many values.
+func selectPath(v interface{}, steps []pathStep) ([]interface{}, error) {
+	if len(steps) == 0 {
+		return []interface{}{v}, nil
+	}
+	step := steps[0]
+	switch {
+	case step.key != "":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("can't select %q from a non-object", step.key)
+		}
+		for k, field := range obj {
+			if normalizeKey(k) == normalizeKey(step.key) {
+				return selectPath(field, steps[1:])
+			}
+		}
+		// omitted empty fields select null
+		return selectPath(nil, steps[1:])
+	case step.all:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("can't iterate over a non-array")
+		}
+		var all []interface{}
+		for _, elem := range arr {
+			vals, err := selectPath(elem, steps[1:])
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, vals...)
+		}
+		return all, nil
+	default:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("can't index a non-array")
+		}
+		if step.index >= len(arr) {
+			return selectPath(nil, steps[1:])
+		}
+		return selectPath(arr[step.index], steps[1:])
+	}
+}
+
+// writeJSONPath writes the values at steps in the json array of players, one compact json
+// value per line as jq does
+func writeJSONPath(w io.Writer, players mlsdata.Players, provenance bool, steps []pathStep) error {
+	buf := &bytes.Buffer{}
+	if err := writeJSON(buf, players, provenance); err != nil {
+		return err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		return err
+	}
+	vals, err := selectPath(doc, steps)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	for _, v := range vals {
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
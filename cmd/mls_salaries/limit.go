@@ -0,0 +1,28 @@
+package main
+
+import "mls_salaries/pkg/mlsdata"
+
+// limitPlayers returns the first n players of each club if byClub is set, or of the league
+// otherwise. players must be sorted by club if byClub is set. If bottom is set the last n
+// players are returned instead, in the same order.
+func limitPlayers(players mlsdata.Players, n int, bottom, byClub bool) mlsdata.Players {
+	var limited mlsdata.Players
+	for start := 0; start < len(players); {
+		end := len(players)
+		if byClub {
+			for end = start; end < len(players) && players[end].Club == players[start].Club; end++ {
+			}
+		}
+		group := players[start:end]
+		if len(group) > n {
+			if bottom {
+				group = group[len(group)-n:]
+			} else {
+				group = group[:n]
+			}
+		}
+		limited = append(limited, group...)
+		start = end
+	}
+	return limited
+}
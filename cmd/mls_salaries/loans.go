@@ -0,0 +1,29 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// readLoans reads a file of player names, one per line, into a set keyed by player name key.
+// Blank lines and lines starting with # are skipped.
+func readLoans(name string) (map[string]bool, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	loans := make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		loans[mlsdata.NameKey(line)] = true
+	}
+	return loans, scanner.Err()
+}
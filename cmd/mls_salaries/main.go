@@ -1,8 +1,6 @@
 package main
 
 import (
-	"bufio"
-	"bytes"
 	"embed"
 	"flag"
 	"fmt"
@@ -11,9 +9,10 @@ import (
 	"log"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
 )
 
 //go:embed data/*
@@ -22,37 +21,143 @@ var dataFS embed.FS
 func usage() {
 	fmt.Printf("Usage of %s:\n", os.Args[0])
 	flag.PrintDefaults()
-	files, err := fs.Glob(dataFS, "data/*_data")
+	files, err := readManifest()
 	check(0, err)
 	if len(files) > 0 {
-		if len(files)%2 != 0 {
-			files = append(files, "data/")
+		fmt.Printf("\ndata files:\n")
+		t := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, f := range files {
+			fmt.Fprintf(t, "  %s\t%s\t%s\t%s\t%d rows\n", f.Name, f.Released, f.Window, f.Format, f.Rows)
 		}
-		fmt.Printf("\ndata files: \n")
-		for i := 0; i < len(files); i += 2 {
-			fmt.Printf("  %s, %s\n", files[i][len("data/"):], files[i+1][len("data/"):])
+		check(0, t.Flush())
+	}
+}
+
+// dataFiles returns the names of the embedded data, csv, and xlsx data files in release order
+func dataFiles() ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"data/*_data", "data/*_data.csv", "data/*_data.xlsx"} {
+		matches, err := fs.Glob(dataFS, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range matches {
+			files = append(files, strings.TrimPrefix(m, "data/"))
 		}
 	}
+	mlsdata.SortReleases(files)
+	return files, nil
+}
+
+// openData opens the named data file from disk, falling back to the embedded data files
+func openData(name string) (io.ReadCloser, error) {
+	if f, err := os.Open(name); err == nil {
+		return f, nil
+	}
+	return dataFS.Open("data/" + name)
 }
 
 func main() {
 	flag.Usage = usage
 	var (
-		all        Players
-		clubs      Clubs
-		players    Players
-		pos        Pos
-		sortByClub = flag.Bool("sort", true, "sort by club")
-		data       = flag.String("data", "2024_09_13_data", "data file")
-		debug      = flag.Bool("debug", false, "print data lines that don't match")
-		dps        = flag.Bool("dp", false, "players making above the maximum Targeted Allocation Money amount")
-		clubTotals = make(ClubTotals, len(allClubs))
+		all           mlsdata.Players
+		counted       mlsdata.Players
+		clubs         mlsdata.Clubs
+		highlight     mlsdata.Clubs
+		mechanism     Mechanisms
+		marketRates   MarketRates
+		players       mlsdata.Players
+		pos           mlsdata.Pos
+		dpThreshold   mlsdata.Money
+		sortByClub    = flag.Bool("sort", true, "sort by club")
+		sortBy        = flag.String("sort-by", "comp", "salary to sort players by: comp (guaranteed compensation) or base (base salary)")
+		topN          = flag.Int("top", 0, "show only the n highest paid players of each club, or of the league with -sort=false")
+		bottomN       = flag.Int("bottom", 0, "show only the n lowest paid players of each club, or of the league with -sort=false")
+		colList       = flag.String("col", "comp", "comma separated salary columns to show: comp (guaranteed compensation), base (base salary)")
+		data          = flag.String("data", "2024_09_13_data", "data file")
+		debug         = flag.Bool("debug", false, "print data lines that don't match")
+		parseIssues   = flag.Bool("report", false, "report the lines of the data file that were skipped or are missing a club, position, or compensation")
+		dps           = flag.Bool("dp", false, "players making above the maximum Targeted Allocation Money amount for the season")
+		charges       = flag.Bool("charges", false, "add a budget charge classification column (budget, TAM, or DP)")
+		clubTotals    = make(mlsdata.ClubTotals, len(mlsdata.AllClubs))
+		clubComps     = make(map[string][]mlsdata.Money, len(mlsdata.AllClubs))
+		totalsSort    = flag.String("totals-sort", "total", "sort club totals by total, average, median, count, or delta (change in total since the previous release)")
+		totalsStat    = flag.String("totals", "sum", "club totals statistic: sum, mean, or median")
+		summary       = flag.Bool("summary", false, "report the mean, standard deviation, and percentiles of compensation per club and league-wide")
+		minPlayers    = flag.Int("min-players", 5, "flag means, medians, and percentiles of fewer than this many players")
+		concentration = flag.Bool("concentration", false, "report the share of league payroll paid to the top 1% and 5% of earners in each data file")
+		chargeTrend   = flag.Bool("charge-trend", false, "report the share of league payroll paid to DP, TAM, and budget charge players in every data file")
+		seasons       = flag.Bool("seasons", false, "report league payroll, players, average, median, DPs, and payroll growth in every data file")
+		premium       = flag.Bool("premium", false, "report how much more the top 10% of each position group is paid than its median, by season")
+		poschanges    = flag.Bool("pos-changes", false, "report players whose position group changed between data files")
+		standings     = flag.String("standings", "", "csv file of club,points records; report payroll per league point")
+		marketValues  = flag.String("market-values", "", "csv file of name,market value records; report players paid most over and under market value")
+		minutesFile   = flag.String("minutes", "", "csv file of name,minutes played records; report club payroll per 90 minutes and pay to players who didn't feature")
+		attendance    = flag.String("attendance", "", "csv file of club,average attendance records; report attendance against payroll")
+		complete      = flag.String("complete", "", "list player names with a word starting with prefix")
+		exportArchive = flag.String("export-all", "", "write every data file, the combined data as parquet, league constants, and a manifest to a zip file")
+		publishDir    = flag.String("publish", "", "write a static site of every data file, with csv and json downloads, to dir (e.g. for GitHub Pages)")
+		sqlDump       = flag.Bool("sql", false, "write an SQL script loading every data file into a normalized schema, e.g. for sqlite3")
+		genNames      = flag.String("gen-names", "", "write the player name index to file (used by go generate)")
+		genManifest   = flag.String("gen-manifest", "", "write the data manifest of every data file to file (used by go generate)")
+		canonical     = flag.String("canonical", "", "write a sorted csv file of each data file to dir (used by go generate)")
+		checkCanon    = flag.String("check-canonical", "", "compare the sorted csv files in dir with the data files and report changed rows")
+		checkClubs    = flag.Bool("check-clubs", false, "report players in every data file listed at a club that wasn't in the league that season")
+		format        = flag.String("format", "text", "output format: text, csv, json, or markdown; -matrix and -waterfall also support html")
+		outputPath    = flag.String("output-path", "", "with -format json, write only the values at a path like players[].compensation, one per line")
+		provenance    = flag.Bool("provenance", false, "include the data file and line number of each player in csv and json output")
+		noCache       = flag.Bool("no-cache", false, "don't use or update the cache of multi-season report output")
+		maxWidth      = flag.Int("max-width", 0, "truncate names and positions longer than this many characters (0 for no limit)")
+		alignRight    = flag.Bool("align-right", false, "right align money columns")
+		noColor       = flag.Bool("no-color", false, "disable colored output")
+		overridesFile = flag.String("overrides", "", "csv file of data,name,club,pos records correcting players in the data files")
+		rulesSrc      = flag.String("rules", "", "json file or url of roster rules, like the constants.json of -export-all, to use instead of the built in rules; a url falls back to its last cached copy")
+		loans         = flag.String("loans", "", "file of players on loan elsewhere, one per line; excluded from club totals")
+		backfill      = flag.Bool("backfill-clubs", true, "give players listed without a club their club in the previous release, marked as inferred")
+		countLoans    = flag.Bool("count-loans", false, "include players listed in -loans in club totals")
+		chartsDir     = flag.String("charts", "", "write SVG charts of club payroll and the compensation distribution of the data file, and of payroll trends up to it, to dir")
+		card          = flag.String("card", "", "write an SVG salary card of the named player")
+		mechFile      = flag.String("mechanisms", "", "csv file of name,mechanism records for the data file; adds a mechanism column")
+		matrix        = flag.Bool("matrix", false, "report the payroll of every club in every season, colored by rank within the season")
+		history       = flag.String("history", "", "report the compensation of players matching name in every data file")
+		byPos         = flag.Bool("by-pos", false, "report spend, player count, and average compensation per position")
+		posGroup      = flag.String("group", "", "position group (GK, D, M, or F); rank clubs by spend on the group")
+		waterfallClub = flag.String("waterfall", "", "club; with -compare, break its wage bill change into new signings, raises, departures, and cuts")
+		compare       = flag.String("compare", "", "two comma separated data files; report raises, cuts, new signings, and departures between them")
+		alertsFile    = flag.String("alerts", "", "json file of salary alert rules; with -compare, report the players and clubs over their thresholds")
+		alertWebhook  = flag.String("alert-webhook", "", "url to post the alerts triggered by -alerts to as json")
+		inflation     = flag.Bool("adjust-inflation", false, "show -compare, -waterfall, and -history values in the dollars of the latest season with a built-in CPI")
 	)
 	log.SetFlags(0)
 	flag.Var(&clubs, "clubs", "comma separated list of mls clubs")
 	flag.Var(&players, "players", "comma separated list of mls players")
 	flag.Var(&pos, "pos", "comma separated list of player positions")
+	flag.Var(&highlight, "highlight", "comma separated list of mls clubs to highlight")
+	flag.Var(&mechanism, "mechanism", "comma separated list of roster mechanisms (requires -mechanisms)")
+	flag.Var(&marketRates, "market-rates", "comma separated `code=rate` pairs, like EUR=1.08,GBP=1.27, converting -market-values in euros and pounds to US dollars")
+	flag.Var(&dpThreshold, "dp-threshold", "`compensation` above which players are designated players (default: the season's TAM maximum)")
+	flag.StringVar(&unit, "unit", unit, "unit of money values: full, k (thousands), or m (millions)")
 	flag.Parse()
+	// casual users running the command bare in a terminal pick a view instead of the whole league
+	if flag.NFlag() == 0 && flag.NArg() == 0 && isTerminal(os.Stdin) && isTerminal(os.Stdout) {
+		if err := prompt(os.Stdout, os.Stdin, data, &clubs, &pos); err != nil {
+			if err == io.EOF {
+				return
+			}
+			log.Fatal(err)
+		}
+		fmt.Println()
+	}
+
+	if err := checkStat(*totalsStat); err != nil {
+		log.Fatal(err)
+	}
+	if err := checkTotalsSort(*totalsSort); err != nil {
+		log.Fatal(err)
+	}
+	if err := checkUnit(unit); err != nil {
+		log.Fatal(err)
+	}
 
 	debugln := func(a ...any) {
 		if *debug {
@@ -60,91 +165,292 @@ func main() {
 		}
 	}
 
-	var r *bufio.Reader
-	f, err := os.Open(*data)
+	var pathSteps []pathStep
+	if *outputPath != "" {
+		if *format != "json" {
+			log.Fatal("-output-path requires -format json")
+		}
+		var err error
+		if pathSteps, err = parseOutputPath(*outputPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+	switch {
+	case *topN < 0 || *bottomN < 0:
+		log.Fatal("-top and -bottom must not be negative")
+	case *topN > 0 && *bottomN > 0:
+		log.Fatal("-top and -bottom can't be used together")
+	}
+	if err := checkCol(*sortBy); err != nil {
+		log.Fatal(err)
+	}
+	cols, err := parseCols(*colList)
 	if err != nil {
-		f, err := dataFS.Open("data/" + *data)
-		if err != nil {
+		log.Fatal(err)
+	}
+
+	if *posGroup != "" {
+		var err error
+		if *posGroup, err = checkGroup(*posGroup); err != nil {
 			log.Fatal(err)
 		}
-		r = bufio.NewReader(f)
-	} else {
-		r = bufio.NewReader(f)
+	}
+	var compareFrom, compareTo string
+	if *compare != "" {
+		var err error
+		if compareFrom, compareTo, err = parseCompare(*compare); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if mechanism != nil && *mechFile == "" {
+		log.Fatal("-mechanism requires -mechanisms")
+	}
+	var alertRules []AlertRule
+	switch {
+	case *alertsFile != "" && (*compare == "" || *waterfallClub != ""):
+		log.Fatal("-alerts requires -compare without -waterfall")
+	case *alertWebhook != "" && *alertsFile == "":
+		log.Fatal("-alert-webhook requires -alerts")
+	case *alertsFile != "":
+		var err error
+		if alertRules, err = readAlertRules(*alertsFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *waterfallClub != "" {
+		club, ok := mlsdata.LookupClub(*waterfallClub)
+		switch {
+		case !ok:
+			log.Fatalf("unknown club %q, valid clubs: %s", *waterfallClub, strings.Join(mlsdata.ClubIDs(), ", "))
+		case *compare == "":
+			log.Fatal("-waterfall requires -compare")
+		case *format != "text" && *format != "html":
+			log.Fatalf("-waterfall supports text and html formats, not %q", *format)
+		}
+		*waterfallClub = club.ID
 	}
 
-	var sep = " "
-	if b, _ := r.ReadByte(); string(b) == "\t" {
-		sep = "\t"
-	} else {
-		_ = r.UnreadByte()
-	}
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		tokens := strings.Split(scanner.Text(), sep)
-		player := Player{}
-		for _, token := range tokens {
-			if token == "" {
-				continue
-			}
-			switch {
-			case allClubs.HasVal(token):
-				player.Club = allClubs.Abv(token)
-
-			case allPos.HasVal(token):
-				player.Pos = token
-
-			case token[0] == '$', token[0] >= '0' && token[0] <= '9':
-				if token = strings.TrimLeft(token, "$"); token == "" {
-					continue
-				}
-
-				val, err := strconv.ParseFloat(strings.Replace(token, ",", "", -1), 32)
-				if err != nil {
-					continue
-				}
-
-				if player.BaseSalary == 0 {
-					player.BaseSalary = val
-				} else {
-					player.Compensation = val
-				}
-
-			default:
-				if player.Name == "" {
-					player.Name = token
-				} else {
-					player.Name += " " + token
-				}
+	// multiSeason writes the output of a report over every data file, cached by the query and data
+	multiSeason := func(report func(io.Writer) error) error {
+		if *noCache {
+			return report(os.Stdout)
+		}
+		var inputs, files []string
+		if *compare != "" {
+			inputs = append(inputs, compareFrom, compareTo)
+		}
+		for _, name := range []string{*overridesFile, *alertsFile, *loans, *mechFile} {
+			if name != "" {
+				files = append(files, name)
 			}
 		}
-		if player.Club == "" && player.Pos == "" && player.Compensation < 30000.00 {
-			debugln("no match:", player)
-			continue
+		key, err := cacheKey(os.Args[1:], inputs, files)
+		if err != nil {
+			return err
 		}
-		if clubs != nil && !clubs.HasVal(player.Club) {
-			continue
+		return cachedReport(os.Stdout, key, report)
+	}
+
+	if *rulesSrc != "" {
+		if err := loadRules(*rulesSrc); err != nil {
+			log.Fatal(err)
 		}
-		if pos != nil && !pos.HasVal(player.Pos) {
-			continue
+	}
+	if *overridesFile != "" {
+		f, err := os.Open(*overridesFile)
+		if err != nil {
+			log.Fatal(err)
 		}
-		if players != nil && !players.HasVal(player.Name) {
-			continue
+		overrides, err = mlsdata.ReadOverrides(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", *overridesFile, err)
 		}
-		if *dps && player.Compensation < 1_612_500 {
+	}
+
+	if *genManifest != "" {
+		check(0, writeManifest(*genManifest))
+		return
+	}
+	if *genNames != "" {
+		check(0, writeNameIndex(*genNames))
+		return
+	}
+	if *canonical != "" {
+		check(0, writeCanonical(*canonical))
+		return
+	}
+	if *checkCanon != "" {
+		check(0, canonicalReport(os.Stdout, *checkCanon))
+		return
+	}
+	if *checkClubs {
+		check(0, clubsReport(os.Stdout))
+		return
+	}
+	if *sqlDump {
+		check(0, writeSQL(os.Stdout, dpThreshold))
+		return
+	}
+	if *exportArchive != "" {
+		check(0, exportAll(*exportArchive))
+		return
+	}
+	if *publishDir != "" {
+		check(0, publish(*publishDir, dpThreshold))
+		return
+	}
+	if *complete != "" {
+		check(0, completeReport(os.Stdout, *complete))
+		return
+	}
+	if *chargeTrend {
+		check(0, multiSeason(func(w io.Writer) error { return chargeTrendReport(w, dpThreshold) }))
+		return
+	}
+	if *seasons {
+		check(0, multiSeason(func(w io.Writer) error { return seasonsReport(w, dpThreshold) }))
+		return
+	}
+	if *premium {
+		check(0, multiSeason(func(w io.Writer) error { return premiumReport(w, *minPlayers) }))
+		return
+	}
+	if *concentration {
+		check(0, multiSeason(concentrationReport))
+		return
+	}
+	if *matrix {
+		switch *format {
+		case "text", "csv", "html":
+		default:
+			log.Fatalf("-matrix supports text, csv, and html formats, not %q", *format)
+		}
+		check(0, matrixReport(os.Stdout, *format, !*noColor && isTerminal(os.Stdout)))
+		return
+	}
+	if *history != "" {
+		check(0, multiSeason(func(w io.Writer) error { return historyReport(w, *history, *inflation) }))
+		return
+	}
+	if *poschanges {
+		check(0, multiSeason(func(w io.Writer) error { return posChangesReport(w, players) }))
+		return
+	}
+
+	f, err := openData(*data)
+	if err != nil {
+		log.Fatal(err)
+	}
+	parser := mlsdata.NewFileParser(*data, f)
+	parser.Overrides = overrides
+	parser.Skipped = func(line int, text string) { debugln("no match:", text) }
+	report := &mlsdata.ParseReport{}
+	parser.Report = report
+	parsed, err := parser.All()
+	f.Close()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *parseIssues {
+		check(0, parseReport(os.Stdout, report, *format))
+		return
+	}
+	keep := func(player mlsdata.Player) bool {
+		switch {
+		case clubs != nil && !clubs.HasVal(player.Club):
+			return false
+		case pos != nil && !pos.HasAny(player.Pos):
+			return false
+		case players != nil && !players.HasVal(player.Name):
+			return false
+		case mechanism != nil && !mechanism.HasVal(player.Mechanism):
+			return false
+		case *dps && player.Charge != mlsdata.ChargeDP:
+			return false
+		case !mlsdata.InLeague(player.Club, mlsdata.DataSeason(player.File)):
+			return false
+		}
+		return true
+	}
+	rules := classify(*data, parsed, dpThreshold)
+	if rules.Estimated && *format != "text" {
+		log.Printf("%s: no roster rules for season %d; estimated %s", *data, rules.Season, describeRules(rules))
+	}
+	if *waterfallClub != "" {
+		check(0, multiSeason(func(w io.Writer) error {
+			return waterfallReport(w, *waterfallClub, compareFrom, compareTo, *format, keep, dpThreshold, *inflation)
+		}))
+		return
+	}
+	if *compare != "" {
+		paint := palette{enabled: !*noColor && isTerminal(os.Stdout)}
+		report := func(w io.Writer) error {
+			return compareReport(w, compareFrom, compareTo, keep, dpThreshold, *inflation, paint, alertRules)
+		}
+		if paint.enabled {
+			// the cache only holds uncolored output
+			check(0, report(os.Stdout))
+		} else {
+			check(0, multiSeason(report))
+		}
+		// alerts are posted on every run, as cached output skips the report
+		if *alertWebhook != "" {
+			releases, err := compareReleases(compareFrom, compareTo, keep, dpThreshold, *inflation)
+			check(0, err)
+			check(0, postAlerts(*alertWebhook, compareFrom, compareTo, compareAlerts(releases[0], releases[1], alertRules)))
+		}
+		return
+	}
+	if *mechFile != "" {
+		mechanisms, err := readMechanisms(*mechFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i := range parsed {
+			parsed[i].Mechanism = mechanisms[mlsdata.NameKey(parsed[i].Name)]
+		}
+	}
+	var loaned map[string]bool
+	if *loans != "" {
+		loaned, err = readLoans(*loans)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for i := range parsed {
+			parsed[i].OnLoan = loaned[mlsdata.NameKey(parsed[i].Name)]
+		}
+	}
+	if prev, ok := previousData(*data); ok && *backfill {
+		prevPlayers, err := loadData(prev, dpThreshold)
+		if err != nil {
+			log.Fatal(err)
+		}
+		debugln("inferred club of", parsed.BackfillClubs(prevPlayers), "players from", prev)
+	}
+
+	for _, player := range parsed {
+		if !keep(player) {
 			continue
 		}
 		if player.Club == "" {
 			debugln("no club", player)
 		}
-		if player.Pos == "" {
+		if len(player.Pos) == 0 {
 			debugln("no pos", player)
 		}
-		if player.Compensation < 30000.00 {
-			debugln("no compensation", player)
-		}
 
 		all = append(all, player)
-		clubTotals[player.Club] += player.Compensation
+		if !player.OnLoan || *countLoans {
+			clubTotals[player.Club] += player.Compensation
+			clubComps[player.Club] = append(clubComps[player.Club], player.Compensation)
+			counted = append(counted, player)
+		}
+	}
+	if *card != "" {
+		// the percentile is over the players the reports show
+		check(0, writeCard(os.Stdout, *data, *card, all))
+		return
 	}
 
 	if len(all) == 0 {
@@ -152,17 +458,84 @@ func main() {
 		return
 	}
 
-	sort.Slice(all, func(i, j int) bool { return all[i].Compensation > all[j].Compensation })
+	if *standings != "" {
+		check(0, pointsReport(os.Stdout, *standings, clubTotals))
+		return
+	}
+	if *marketValues != "" {
+		check(0, marketReport(os.Stdout, *marketValues, marketRates, all))
+		return
+	}
+	if *chartsDir != "" {
+		check(0, writeCharts(*chartsDir, *data, counted, clubTotals))
+		return
+	}
+	if *minutesFile != "" {
+		check(0, minutesReport(os.Stdout, *minutesFile, counted))
+		return
+	}
+	if *attendance != "" {
+		check(0, attendanceReport(os.Stdout, *attendance, clubTotals))
+		return
+	}
+	if *summary {
+		check(0, summaryReport(os.Stdout, clubComps, *minPlayers))
+		return
+	}
+	if *byPos {
+		check(0, posReport(os.Stdout, counted, *minPlayers))
+		return
+	}
+	if *posGroup != "" {
+		check(0, groupReport(os.Stdout, *posGroup, all, clubTotals, *countLoans, *minPlayers))
+		return
+	}
+
+	sort.Slice(all, func(i, j int) bool { return salary(all[i], *sortBy) > salary(all[j], *sortBy) })
 	if *sortByClub {
 		sort.SliceStable(all, func(i, j int) bool { return all[i].Club < all[j].Club })
 	}
+	if *topN > 0 {
+		all = limitPlayers(all, *topN, false, *sortByClub)
+	} else if *bottomN > 0 {
+		all = limitPlayers(all, *bottomN, true, *sortByClub)
+	}
+	switch *format {
+	case "csv":
+		check(0, writeCSV(os.Stdout, all, *provenance))
+		return
+	case "json":
+		if pathSteps != nil {
+			check(0, writeJSONPath(os.Stdout, all, *provenance, pathSteps))
+			return
+		}
+		check(0, writeJSON(os.Stdout, all, *provenance))
+		return
+	case "markdown":
+		check(0, writeMarkdown(os.Stdout, all, cols))
+		return
+	case "text":
+	default:
+		log.Fatalf("unknown format %q", *format)
+	}
 	var w io.Writer
 	if !*debug {
 		w = os.Stdout
 	} else {
 		w = io.Discard
 	}
+	paint := palette{enabled: !*noColor && !*debug && isTerminal(os.Stdout), highlight: highlight}
 	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	colWidths := make([]int, len(cols))
+	if *alignRight {
+		for c, col := range cols {
+			vals := make([]mlsdata.Money, len(all))
+			for i, p := range all {
+				vals[i] = salary(p, col)
+			}
+			colWidths[c] = moneyWidth(vals)
+		}
+	}
 	i := 1
 	lastClub := all[0].Club
 	for _, data := range all {
@@ -171,49 +544,139 @@ func main() {
 			lastClub = data.Club
 			check(fmt.Fprintln(t))
 		}
-		check(fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\n", i, data.Club, data.Pos, data.Name, commaf(data.Compensation)))
+		start, end := paint.row(data.Club)
+		name := paint.name(truncate(data.Name, *maxWidth), data.Charge == mlsdata.ChargeDP)
+		if data.OnLoan {
+			name += " (loan)"
+		}
+		if data.ClubInferred {
+			name += " (club inferred)"
+		}
+		if *charges && rules.Estimated {
+			name += "\t" + data.Charge + "*"
+		} else if *charges {
+			name += "\t" + data.Charge
+		}
+		if *mechFile != "" {
+			name += "\t" + data.Mechanism
+		}
+		var salaries []string
+		for c, col := range cols {
+			salaries = append(salaries, fmt.Sprintf("%*s", colWidths[c], money(salary(data, col))))
+		}
+		check(fmt.Fprintf(t, "%s%d\t%s\t%s\t%s\t%s%s\n", start, i, paint.club(data.Club), truncate(data.Pos.Label(), *maxWidth), name, strings.Join(salaries, "\t"), end))
 		i++
 	}
 
-	check(fmt.Fprintf(t, "\n\n"))
-	for i, v := range clubTotals.Sort() {
-		check(fmt.Fprintf(t, "%d\t%s\ttotal: %s\n", i+1, v.Key, commaf(v.Value)))
+	// rank movement is shown when there is a previous release to compare with
+	var prevRanks map[string]int
+	var prevTotals mlsdata.ClubTotals
+	if prev, ok := previousData(*data); ok {
+		prevPlayers, err := loadData(prev, dpThreshold)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// the previous release gets the same club backfill and loan exclusion
+		if before, ok := previousData(prev); ok && *backfill {
+			beforePlayers, err := loadData(before, dpThreshold)
+			if err != nil {
+				log.Fatal(err)
+			}
+			prevPlayers.BackfillClubs(beforePlayers)
+		}
+		prevComps := make(map[string][]mlsdata.Money)
+		for _, player := range prevPlayers {
+			player.OnLoan = loaned[mlsdata.NameKey(player.Name)]
+			if keep(player) && (!player.OnLoan || *countLoans) {
+				prevComps[player.Club] = append(prevComps[player.Club], player.Compensation)
+			}
+		}
+		prevTotals, err = aggregate(prevComps, *totalsStat)
+		if err != nil {
+			log.Fatal(err)
+		}
+		prevRanks = ranks(prevTotals)
 	}
-	err = t.Flush()
+
+	shown, err := aggregate(clubComps, *totalsStat)
 	if err != nil {
 		log.Fatal(err)
 	}
-	debugln()
-}
-
-// commaf returns v as a string with commas added
-func commaf(v float64) string {
-	buf := &bytes.Buffer{}
-	if v < 0 {
-		buf.Write([]byte{'-'})
-		v = 0 - v
+	label := *totalsStat
+	if label == "sum" {
+		label = "total"
 	}
-
-	comma := []byte{','}
-
-	parts := strings.Split(strconv.FormatFloat(v, 'f', 2, 64), ".")
-	pos := 0
-	if len(parts[0])%3 != 0 {
-		pos += len(parts[0]) % 3
-		buf.WriteString(parts[0][:pos])
-		buf.Write(comma)
+	summaries := mlsdata.Summarize(counted)
+	sorted := shown.Sort()
+	currentRanks := ranks(shown)
+	sortTotals(sorted, *totalsSort, summaries, prevTotals)
+	// z-scores place each club's value against the mean of the clubs, leaving out the player
+	// pool and players without a club, in standard deviations
+	var values []float64
+	for _, v := range sorted {
+		if v.Key != "" && v.Key != "MLS" {
+			values = append(values, v.Value.Float())
+		}
 	}
-	for ; pos < len(parts[0]); pos += 3 {
-		buf.WriteString(parts[0][pos : pos+3])
-		buf.Write(comma)
+	mu, sigma := mean(values), stddev(values)
+	var leagueTotal mlsdata.Money
+	for _, s := range summaries {
+		leagueTotal += s.Compensation
 	}
-	buf.Truncate(buf.Len() - 1)
-
-	if len(parts) > 1 {
-		buf.Write([]byte{'.'})
-		buf.WriteString(parts[1])
+	valueWidth, baseWidth, countWidth := 0, 0, 0
+	if *alignRight {
+		var vals, bases []mlsdata.Money
+		for _, v := range sorted {
+			vals = append(vals, v.Value)
+			bases = append(bases, summaries[v.Key].BaseSalary)
+			if n := len(fmt.Sprint(summaries[v.Key].Players)); n > countWidth {
+				countWidth = n
+			}
+		}
+		valueWidth, baseWidth = moneyWidth(vals), moneyWidth(bases)
+	}
+	check(fmt.Fprintf(t, "\n\n"))
+	for i, v := range sorted {
+		start, end := paint.row(v.Key)
+		move := ""
+		if rank, ok := currentRanks[v.Key]; ok && prevRanks != nil {
+			move = "\t" + rankMove(v.Key, rank, prevRanks, mlsdata.DataSeason(*data))
+		} else if prevRanks != nil {
+			move = "\t"
+		}
+		// the avg and median columns are shown for every stat
+		if note := fewPlayers(len(clubComps[v.Key]), *minPlayers); note != "" {
+			move += "\t" + note
+		}
+		s := summaries[v.Key]
+		// the pool and players without a club aren't part of the distribution
+		z := ""
+		if v.Key != "" && v.Key != "MLS" {
+			score := 0.0
+			if sigma > 0 {
+				score = (v.Value.Float() - mu) / sigma
+			}
+			z = fmt.Sprintf("z: %+.2f", score)
+		}
+		share := 0.0
+		if leagueTotal > 0 {
+			share = s.Compensation.Float() / leagueTotal.Float() * 100
+		}
+		check(fmt.Fprintf(t, "%s%d\t%s\t%s: %*s\t%s\tshare: %.1f%%\t%*d players\tbase: %*s\tavg: %s\tmedian: %s\ttop: %s%s%s\n",
+			start, i+1, paint.club(v.Key), label, valueWidth, money(v.Value), z, share, countWidth, s.Players, baseWidth, money(s.BaseSalary),
+			money(s.Average), money(s.Median), truncate(s.Top.Name, *maxWidth), move, end))
+	}
+	if rules.Estimated {
+		check(fmt.Fprintf(t, "\n* estimated %d roster rules: %s\n", rules.Season, describeRules(rules)))
 	}
-	return buf.String()
+	if *dps || *charges {
+		check(fmt.Fprintf(t, "\nDP threshold for %s: %s\n", *data, money(rules.DPThreshold())))
+	}
+	err = t.Flush()
+	if err != nil {
+		log.Fatal(err)
+	}
+	debugln()
 }
 
 func check(_ interface{}, err error) {
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+//go:generate go run . -gen-manifest data/manifest.json
+
+// DataFile describes one data file in the data manifest
+type DataFile struct {
+	Name   string `json:"name"`
+	Season int    `json:"season"`
+	// Released is the release date as YYYY-MM-DD, and Window the part of the season it was in
+	Released string `json:"released,omitempty"`
+	Window   string `json:"window,omitempty"`
+	// Source is where the release was published. It isn't derived from the file, so it is kept
+	// from the previous manifest when the manifest is regenerated.
+	Source string `json:"source,omitempty"`
+	Format string `json:"format"`
+	Rows   int    `json:"rows"`
+	SHA256 string `json:"sha256"`
+}
+
+// dataFormat returns the format of the named data file: csv, xlsx, or text
+func dataFormat(name string) string {
+	switch ext := strings.ToLower(path.Ext(name)); ext {
+	case ".csv", ".xlsx":
+		return ext[1:]
+	default:
+		return "text"
+	}
+}
+
+// readManifest returns the data files listed in the embedded data manifest, in release order
+func readManifest() ([]DataFile, error) {
+	b, err := fs.ReadFile(dataFS, "data/manifest.json")
+	if err != nil {
+		return nil, err
+	}
+	var files []DataFile
+	err = json.Unmarshal(b, &files)
+	return files, err
+}
+
+// writeManifest writes the data manifest of every embedded data file to the file name
+func writeManifest(name string) error {
+	sources := make(map[string]string)
+	if b, err := os.ReadFile(name); err == nil {
+		var prev []DataFile
+		if err := json.Unmarshal(b, &prev); err != nil {
+			return err
+		}
+		for _, f := range prev {
+			sources[f.Name] = f.Source
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	files := make([]DataFile, 0, len(releases))
+	for _, r := range releases {
+		f, err := openData(r.Data)
+		if err != nil {
+			return err
+		}
+		h := sha256.New()
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+		season := mlsdata.ParseSeason(r.Data)
+		df := DataFile{
+			Name:   r.Data,
+			Season: season.Year,
+			Window: season.Window(),
+			Source: sources[r.Data],
+			Format: dataFormat(r.Data),
+			Rows:   len(r.Players),
+			SHA256: hex.EncodeToString(h.Sum(nil)),
+		}
+		if !season.Released.IsZero() {
+			df.Released = season.Released.Format("2006-01-02")
+		}
+		files = append(files, df)
+	}
+	b, err := json.MarshalIndent(files, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(b, '\n'), 0o644)
+}
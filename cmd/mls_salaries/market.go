@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// marketFlagged is the number of players listed in each of the over and under paid sections
+const marketFlagged = 10
+
+// currencySymbols maps the currency symbols of market values to their currency codes
+var currencySymbols = map[string]string{"$": "USD", "€": "EUR", "£": "GBP"}
+
+// MarketRates maps currency codes to their value in US dollars, converting market values
+type MarketRates map[string]float64
+
+// Set sets the value of r from a comma separated list of code=rate pairs, like EUR=1.08
+func (r *MarketRates) Set(s string) error {
+	*r = make(MarketRates)
+	for _, pair := range strings.Split(s, ",") {
+		code, rate, ok := strings.Cut(pair, "=")
+		f, err := strconv.ParseFloat(strings.TrimSpace(rate), 64)
+		if !ok || err != nil || f <= 0 {
+			return fmt.Errorf("invalid rate %q, want a code=rate pair like EUR=1.08", pair)
+		}
+		(*r)[strings.ToUpper(strings.TrimSpace(code))] = f
+	}
+	return nil
+}
+
+func (r *MarketRates) String() string {
+	var pairs []string
+	for code, rate := range *r {
+		pairs = append(pairs, fmt.Sprintf("%s=%g", code, rate))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// parseMarketValue parses a market value like "€12.50m", "$800k", "750Th." or "1,200,000" in US
+// dollars. Euro and pound values are converted with rates, and rejected if rates has none for
+// their currency. Values with no currency symbol are in US dollars.
+func parseMarketValue(s string, rates MarketRates) (mlsdata.Money, error) {
+	v := strings.ToLower(strings.TrimSpace(s))
+	rate := 1.0
+	for symbol, code := range currencySymbols {
+		if !strings.HasPrefix(v, symbol) {
+			continue
+		}
+		v = strings.TrimPrefix(v, symbol)
+		if code != "USD" {
+			var ok bool
+			if rate, ok = rates[code]; !ok {
+				return 0, fmt.Errorf("market value %q in %s needs a rate in -market-rates", s, code)
+			}
+		}
+	}
+	v = strings.Replace(v, ",", "", -1)
+	mult := 1.0
+	switch {
+	case strings.HasSuffix(v, "bn"):
+		mult, v = 1e9, strings.TrimSuffix(v, "bn")
+	case strings.HasSuffix(v, "m"):
+		mult, v = 1e6, strings.TrimSuffix(v, "m")
+	case strings.HasSuffix(v, "th."):
+		mult, v = 1e3, strings.TrimSuffix(v, "th.")
+	case strings.HasSuffix(v, "k"):
+		mult, v = 1e3, strings.TrimSuffix(v, "k")
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid market value %q", s)
+	}
+	return mlsdata.Dollars(f * mult * rate), nil
+}
+
+// readMarketValues reads a csv file of name,market value records into a map keyed by player name key,
+// converting values to US dollars with rates. A header row is skipped.
+func readMarketValues(name string, rates MarketRates) (map[string]mlsdata.Money, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	values := make(map[string]mlsdata.Money)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		val, err := parseMarketValue(record[1], rates)
+		if err != nil {
+			// a header has no digits; a first value in a currency without a rate is still an error
+			if line == 1 && !strings.ContainsAny(record[1], "0123456789") {
+				continue
+			}
+			return nil, fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+		values[mlsdata.NameKey(record[0])] = val
+	}
+	return values, nil
+}
+
+// MarketValue compares a player's compensation to their estimated market value
+type MarketValue struct {
+	Player mlsdata.Player
+	Value  mlsdata.Money
+}
+
+// Diff returns how much more the player is paid than their market value
+func (m MarketValue) Diff() mlsdata.Money { return m.Player.Compensation - m.Value }
+
+// marketReport writes the players paid the most over and under their market value using the
+// market value file name, converted to US dollars with rates
+func marketReport(w io.Writer, name string, rates MarketRates, players mlsdata.Players) error {
+	values, err := readMarketValues(name, rates)
+	if err != nil {
+		return err
+	}
+	var matched []MarketValue
+	for _, p := range players {
+		if v, ok := values[mlsdata.NameKey(p.Name)]; ok {
+			matched = append(matched, MarketValue{Player: p, Value: v})
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Diff() > matched[j].Diff() })
+
+	// a player is listed as overpaid or underpaid, not both
+	n := marketFlagged
+	if n > len(matched)/2 {
+		n = len(matched) / 2
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	section := func(title string, list []MarketValue) {
+		fmt.Fprintf(t, "%s\n", title)
+		for i, m := range list {
+			fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, m.Player.Club, m.Player.Name,
+				money(m.Player.Compensation), money(m.Value), money(m.Diff()))
+		}
+	}
+	section("overpaid\tclub\tname\tcompensation\tmarket value\tdifference", matched[:n])
+	underpaid := make([]MarketValue, n)
+	for i := range underpaid {
+		underpaid[i] = matched[len(matched)-1-i]
+	}
+	fmt.Fprintln(t)
+	section("underpaid\tclub\tname\tcompensation\tmarket value\tdifference", underpaid)
+	fmt.Fprintf(t, "\nmatched %d of %d players\n", len(matched), len(players))
+	return t.Flush()
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// heatANSI and heatHTML are the background colors of payroll cells, from the lowest fifth of a season's
+// payrolls to the highest, as 256 color ANSI codes of equal length and as HTML colors
+var (
+	heatANSI = []string{"\x1b[48;5;024m", "\x1b[48;5;030m", "\x1b[48;5;058m", "\x1b[48;5;130m", "\x1b[48;5;124m"}
+	heatHTML = []string{"#2b6b9f", "#3a9c9c", "#9c9c3a", "#c77a2e", "#b83232"}
+)
+
+// heatNone is the same length as the heatANSI codes and leaves the background as is
+const heatNone = "\x1b[0;39;49m"
+
+// PayrollMatrix is the total payroll of each club in each season
+type PayrollMatrix struct {
+	Seasons []int
+	Clubs   []string
+	Payroll map[string]map[int]mlsdata.Money // by club, then season
+}
+
+// payrollMatrix returns the payroll of each club in the latest release of each season, leaving out
+// clubs not yet in the league
+func payrollMatrix(releases []Release) PayrollMatrix {
+	latest := make(map[int]Release)
+	for _, r := range releases {
+		latest[mlsdata.DataSeason(r.Data)] = r
+	}
+	m := PayrollMatrix{Payroll: make(map[string]map[int]mlsdata.Money)}
+	for season, r := range latest {
+		m.Seasons = append(m.Seasons, season)
+		for _, p := range r.Players {
+			if p.Club == "" || !mlsdata.InLeague(p.Club, season) {
+				continue
+			}
+			if m.Payroll[p.Club] == nil {
+				m.Payroll[p.Club] = make(map[int]mlsdata.Money)
+				m.Clubs = append(m.Clubs, p.Club)
+			}
+			m.Payroll[p.Club][season] += p.Compensation
+		}
+	}
+	sort.Ints(m.Seasons)
+	sort.Strings(m.Clubs)
+	return m
+}
+
+// heat returns the heat level of each club's payroll within each season, by club and season
+func (m PayrollMatrix) heat() map[string]map[int]int {
+	levels := make(map[string]map[int]int, len(m.Clubs))
+	for _, club := range m.Clubs {
+		levels[club] = make(map[int]int)
+	}
+	for _, season := range m.Seasons {
+		var clubs []string
+		for _, club := range m.Clubs {
+			if _, ok := m.Payroll[club][season]; ok {
+				clubs = append(clubs, club)
+			}
+		}
+		sort.Slice(clubs, func(i, j int) bool { return m.Payroll[clubs[i]][season] < m.Payroll[clubs[j]][season] })
+		for i, club := range clubs {
+			levels[club][season] = i * len(heatANSI) / len(clubs)
+		}
+	}
+	return levels
+}
+
+// matrixReport writes the payroll matrix of every data file as a text table, colored by heat
+// level if color is set, as csv, or as an HTML table fragment
+func matrixReport(w io.Writer, format string, color bool) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	m := payrollMatrix(releases)
+	heat := m.heat()
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		header := []string{"club"}
+		for _, season := range m.Seasons {
+			header = append(header, strconv.Itoa(season))
+		}
+		cw.Write(header)
+		for _, club := range m.Clubs {
+			record := []string{club}
+			for _, season := range m.Seasons {
+				v, ok := m.Payroll[club][season]
+				if ok {
+					record = append(record, strconv.FormatFloat(v.Float(), 'f', 2, 64))
+				} else {
+					record = append(record, "")
+				}
+			}
+			cw.Write(record)
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case "html":
+		fmt.Fprintln(w, `<table class="payroll-matrix">`)
+		fmt.Fprint(w, "<thead><tr><th>club</th>")
+		for _, season := range m.Seasons {
+			fmt.Fprintf(w, "<th>%d</th>", season)
+		}
+		fmt.Fprintln(w, "</tr></thead>")
+		fmt.Fprintln(w, "<tbody>")
+		for _, club := range m.Clubs {
+			fmt.Fprintf(w, "<tr><th>%s</th>", html.EscapeString(club))
+			for _, season := range m.Seasons {
+				if v, ok := m.Payroll[club][season]; ok {
+					fmt.Fprintf(w, `<td style="background:%s">%s</td>`, heatHTML[heat[club][season]], money(v))
+				} else {
+					fmt.Fprint(w, "<td></td>")
+				}
+			}
+			fmt.Fprintln(w, "</tr>")
+		}
+		fmt.Fprintln(w, "</tbody>")
+		_, err := fmt.Fprintln(w, "</table>")
+		return err
+	}
+
+	// right aligned empty cells pad the ends of lines, so the table is trimmed before writing
+	buf := &bytes.Buffer{}
+	t := tabwriter.NewWriter(buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(t, "club\t")
+	for _, season := range m.Seasons {
+		fmt.Fprintf(t, "%d\t", season)
+	}
+	fmt.Fprintln(t)
+	for _, club := range m.Clubs {
+		fmt.Fprintf(t, "%s\t", club)
+		for _, season := range m.Seasons {
+			v, ok := m.Payroll[club][season]
+			cell := ""
+			if ok {
+				cell = money(v)
+			}
+			switch {
+			case !color:
+			case ok:
+				cell = heatANSI[heat[club][season]] + cell + ansiReset
+			default:
+				cell = heatNone + cell + ansiReset
+			}
+			fmt.Fprintf(t, "%s\t", cell)
+		}
+		fmt.Fprintln(t)
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
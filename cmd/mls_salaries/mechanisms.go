@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// mechanismNames maps roster mechanism names to their abbreviation
+var mechanismNames = map[string]string{
+	"DP":                "DP",
+	"DESIGNATED PLAYER": "DP",
+	"TAM":               "TAM",
+	"U22":               "U22",
+	"U22 INITIATIVE":    "U22",
+	"HG":                "HG",
+	"HOMEGROWN":         "HG",
+	"SEI":               "SEI",
+}
+
+// Mechanisms is a list of roster mechanisms
+type Mechanisms []string
+
+var allMechanisms = Mechanisms{"DP", "TAM", "U22", "HG", "SEI"}
+
+// HasVal returns true if the mechanism s is in m
+func (m *Mechanisms) HasVal(s string) bool {
+	for _, mech := range *m {
+		if mech == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Set sets the value of m from a comma separated list of mechanisms
+func (m *Mechanisms) Set(s string) error {
+	for _, name := range strings.Split(s, ",") {
+		mech, ok := mechanismNames[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return fmt.Errorf("valid values: %s", allMechanisms.String())
+		}
+		*m = append(*m, mech)
+	}
+	return nil
+}
+
+func (m *Mechanisms) String() string { return strings.Join(*m, ", ") }
+
+// readMechanisms reads a csv file of name,mechanism records into a map keyed by player name key.
+// A header row is skipped.
+func readMechanisms(name string) (map[string]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	mechanisms := make(map[string]string)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		mech, ok := mechanismNames[strings.ToUpper(strings.TrimSpace(record[1]))]
+		if !ok {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%s:%d: unknown roster mechanism %q", name, line, record[1])
+		}
+		mechanisms[mlsdata.NameKey(record[0])] = mech
+	}
+	return mechanisms, nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// idleMinutes is the number of minutes below which a player is counted as not featuring,
+// five full matches
+const idleMinutes = 450
+
+// idleFlagged is the number of highest paid players who didn't feature that are listed
+const idleFlagged = 10
+
+// readMinutes reads a csv file of name,minutes records into a map keyed by player name key.
+// A header row is skipped and the minutes of repeated names are added together.
+func readMinutes(name string) (map[string]float64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	minutes := make(map[string]float64)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		val, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(record[1]), ",", "", -1), 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%s:%d: invalid minutes %q", name, line, record[1])
+		}
+		minutes[mlsdata.NameKey(record[0])] += val
+	}
+	return minutes, nil
+}
+
+// ClubMinutes is a club's payroll against the minutes played by its players
+type ClubMinutes struct {
+	Club     string
+	Payroll  mlsdata.Money
+	Minutes  float64
+	IdlePay  mlsdata.Money // compensation of players with fewer than idleMinutes
+	Per90    mlsdata.Money // payroll per 90 minutes played
+	IdleRate float64       // IdlePay as a percentage of Payroll
+}
+
+// clubMinutes returns the payroll per 90 minutes of each club in players, highest first, and the
+// players with fewer than idleMinutes, highest paid first. Players missing from minutes played none.
+func clubMinutes(players mlsdata.Players, minutes map[string]float64) ([]ClubMinutes, mlsdata.Players) {
+	byClub := make(map[string]*ClubMinutes)
+	var idle mlsdata.Players
+	for _, p := range players {
+		c, ok := byClub[p.Club]
+		if !ok {
+			c = &ClubMinutes{Club: p.Club}
+			byClub[p.Club] = c
+		}
+		m := minutes[mlsdata.NameKey(p.Name)]
+		c.Payroll += p.Compensation
+		c.Minutes += m
+		if m < idleMinutes {
+			c.IdlePay += p.Compensation
+			idle = append(idle, p)
+		}
+	}
+	var clubs []ClubMinutes
+	for _, c := range byClub {
+		if c.Minutes > 0 {
+			c.Per90 = c.Payroll.Scale(90 / c.Minutes)
+		}
+		if c.Payroll > 0 {
+			c.IdleRate = c.IdlePay.Float() / c.Payroll.Float() * 100
+		}
+		clubs = append(clubs, *c)
+	}
+	sort.Slice(clubs, func(i, j int) bool { return clubs[i].Per90 > clubs[j].Per90 })
+	sort.SliceStable(idle, func(i, j int) bool { return idle[i].Compensation > idle[j].Compensation })
+	return clubs, idle
+}
+
+// minutesReport writes each club's payroll per 90 minutes played using the minutes file name,
+// and the highest paid players who didn't feature
+func minutesReport(w io.Writer, name string, players mlsdata.Players) error {
+	minutes, err := readMinutes(name)
+	if err != nil {
+		return err
+	}
+	clubs, idle := clubMinutes(players, minutes)
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "\tclub\tpayroll\tminutes\tper 90\tidle pay\tidle %%\n")
+	for i, c := range clubs {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%.0f\t%s\t%s\t%.1f%%\n", i+1, c.Club, money(c.Payroll), c.Minutes,
+			money(c.Per90), money(c.IdlePay), c.IdleRate)
+	}
+	if len(idle) > idleFlagged {
+		idle = idle[:idleFlagged]
+	}
+	fmt.Fprintf(t, "\nunder %d minutes\tclub\tname\tcompensation\tminutes\n", idleMinutes)
+	for i, p := range idle {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%s\t%.0f\n", i+1, p.Club, p.Name, money(p.Compensation), minutes[mlsdata.NameKey(p.Name)])
+	}
+	return t.Flush()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+//go:generate go run . -gen-names names.idx
+
+// namesIdx holds one "folded name\tname" line per player across all data files, sorted by folded name
+//
+//go:embed names.idx
+var namesIdx string
+
+// writeNameIndex writes the player name index of all embedded data files to the file name
+func writeNameIndex(name string) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	// later releases list first names first, so the most recent spelling wins
+	names := make(map[string]string)
+	for _, r := range releases {
+		for _, p := range r.Players {
+			names[mlsdata.NameKey(p.Name)] = p.Name
+		}
+	}
+	lines := make([]string, 0, len(names))
+	for _, n := range names {
+		lines = append(lines, mlsdata.FoldName(n)+"\t"+n)
+	}
+	sort.Strings(lines)
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// completeNames returns the indexed player names that have a word starting with prefix
+func completeNames(prefix string) []string {
+	prefix = mlsdata.FoldName(strings.TrimSpace(prefix))
+	var names []string
+	for _, line := range strings.Split(namesIdx, "\n") {
+		folded, name, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(folded, prefix) || strings.Contains(folded, " "+prefix) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// completeReport writes the player names completing prefix, one per line
+func completeReport(w io.Writer, prefix string) error {
+	for _, name := range completeNames(prefix) {
+		if _, err := fmt.Fprintln(w, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
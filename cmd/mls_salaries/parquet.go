@@ -0,0 +1,262 @@
+package main
+
+import (
+	"encoding/binary"
+	"io"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// Parquet physical types, converted types, and enum values used by writeParquet, from the
+// parquet-format thrift definitions
+const (
+	parquetBoolean   = 0
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetUTF8    = 0
+	parquetDecimal = 5
+
+	parquetRequired     = 0
+	parquetPlain        = 0
+	parquetRLE          = 3
+	parquetDataPage     = 0
+	parquetUncompressed = 0
+)
+
+// parquetMagic starts and ends a parquet file
+const parquetMagic = "PAR1"
+
+// Thrift compact protocol field types
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftEncoder encodes a struct in the Thrift compact protocol, the encoding of parquet
+// metadata. Fields must be added in increasing id order.
+type thriftEncoder struct {
+	b    []byte
+	last int16
+}
+
+func (e *thriftEncoder) varint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	e.b = append(e.b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+func (e *thriftEncoder) zigzag(v int64) {
+	e.varint(uint64(v<<1) ^ uint64(v>>63))
+}
+
+func (e *thriftEncoder) field(id int16, typ byte) {
+	if d := id - e.last; d > 0 && d <= 15 {
+		e.b = append(e.b, byte(d)<<4|typ)
+	} else {
+		e.b = append(e.b, typ)
+		e.zigzag(int64(id))
+	}
+	e.last = id
+}
+
+func (e *thriftEncoder) i32(id int16, v int32) {
+	e.field(id, thriftI32)
+	e.zigzag(int64(v))
+}
+
+func (e *thriftEncoder) i64(id int16, v int64) {
+	e.field(id, thriftI64)
+	e.zigzag(v)
+}
+
+func (e *thriftEncoder) str(id int16, s string) {
+	e.field(id, thriftBinary)
+	e.varint(uint64(len(s)))
+	e.b = append(e.b, s...)
+}
+
+func (e *thriftEncoder) listHeader(id int16, elem byte, n int) {
+	e.field(id, thriftList)
+	if n < 15 {
+		e.b = append(e.b, byte(n)<<4|elem)
+		return
+	}
+	e.b = append(e.b, 0xf0|elem)
+	e.varint(uint64(n))
+}
+
+func (e *thriftEncoder) i32s(id int16, vs ...int32) {
+	e.listHeader(id, thriftI32, len(vs))
+	for _, v := range vs {
+		e.zigzag(int64(v))
+	}
+}
+
+func (e *thriftEncoder) strs(id int16, ss ...string) {
+	e.listHeader(id, thriftBinary, len(ss))
+	for _, s := range ss {
+		e.varint(uint64(len(s)))
+		e.b = append(e.b, s...)
+	}
+}
+
+func (e *thriftEncoder) structs(id int16, structs ...*thriftEncoder) {
+	e.listHeader(id, thriftStruct, len(structs))
+	for _, s := range structs {
+		e.b = append(e.b, s.bytes()...)
+	}
+}
+
+func (e *thriftEncoder) child(id int16, s *thriftEncoder) {
+	e.field(id, thriftStruct)
+	e.b = append(e.b, s.bytes()...)
+}
+
+// bytes returns the encoded struct, ending with its stop field
+func (e *thriftEncoder) bytes() []byte {
+	return append(e.b[:len(e.b):len(e.b)], 0)
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// parquetColumn is a required column of writeParquet, with its values plain encoded
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted int32 // -1 for none
+	values    []byte
+}
+
+// parquetColumns returns the columns of players: the csv columns with provenance, with money in
+// cents as decimals
+func parquetColumns(players mlsdata.Players) []parquetColumn {
+	str := func(name string, v func(mlsdata.Player) string) parquetColumn {
+		c := parquetColumn{name: name, typ: parquetByteArray, converted: parquetUTF8}
+		for _, p := range players {
+			c.values = appendUint32(c.values, uint32(len(v(p))))
+			c.values = append(c.values, v(p)...)
+		}
+		return c
+	}
+	cents := func(name string, v func(mlsdata.Player) mlsdata.Money) parquetColumn {
+		c := parquetColumn{name: name, typ: parquetInt64, converted: parquetDecimal}
+		for _, p := range players {
+			c.values = appendUint64(c.values, uint64(v(p)))
+		}
+		return c
+	}
+	boolean := func(name string, v func(mlsdata.Player) bool) parquetColumn {
+		// booleans are bit packed, least significant bit first
+		c := parquetColumn{name: name, typ: parquetBoolean, converted: -1, values: make([]byte, (len(players)+7)/8)}
+		for i, p := range players {
+			if v(p) {
+				c.values[i/8] |= 1 << (i % 8)
+			}
+		}
+		return c
+	}
+	line := parquetColumn{name: "line", typ: parquetInt32, converted: -1}
+	for _, p := range players {
+		line.values = appendUint32(line.values, uint32(p.Line))
+	}
+	return []parquetColumn{
+		str("club", func(p mlsdata.Player) string { return p.Club }),
+		str("name", func(p mlsdata.Player) string { return p.Name }),
+		str("pos", func(p mlsdata.Player) string { return p.Pos.Label() }),
+		cents("base_salary", func(p mlsdata.Player) mlsdata.Money { return p.BaseSalary }),
+		cents("compensation", func(p mlsdata.Player) mlsdata.Money { return p.Compensation }),
+		str("file", func(p mlsdata.Player) string { return p.File }),
+		line,
+		boolean("overridden", func(p mlsdata.Player) bool { return p.Overridden }),
+		boolean("club_inferred", func(p mlsdata.Player) bool { return p.ClubInferred }),
+	}
+}
+
+// writeParquet writes players as an uncompressed parquet file of one row group, with a data page
+// per column
+func writeParquet(w io.Writer, players mlsdata.Players) error {
+	columns := parquetColumns(players)
+	out := []byte(parquetMagic)
+	schema := []*thriftEncoder{{}}
+	schema[0].str(4, "schema")
+	schema[0].i32(5, int32(len(columns)))
+	var chunks []*thriftEncoder
+	var total int64
+	for _, c := range columns {
+		el := &thriftEncoder{}
+		el.i32(1, c.typ)
+		el.i32(3, parquetRequired)
+		el.str(4, c.name)
+		if c.converted >= 0 {
+			el.i32(6, c.converted)
+		}
+		if c.converted == parquetDecimal {
+			el.i32(7, 2)
+			el.i32(8, 18)
+		}
+		schema = append(schema, el)
+
+		page := &thriftEncoder{}
+		page.i32(1, parquetDataPage)
+		page.i32(2, int32(len(c.values)))
+		page.i32(3, int32(len(c.values)))
+		data := &thriftEncoder{}
+		data.i32(1, int32(len(players)))
+		data.i32(2, parquetPlain)
+		data.i32(3, parquetRLE)
+		data.i32(4, parquetRLE)
+		page.child(5, data)
+		header := page.bytes()
+
+		offset := int64(len(out))
+		out = append(out, header...)
+		out = append(out, c.values...)
+		size := int64(len(header) + len(c.values))
+		total += size
+
+		meta := &thriftEncoder{}
+		meta.i32(1, c.typ)
+		meta.i32s(2, parquetPlain, parquetRLE)
+		meta.strs(3, c.name)
+		meta.i32(4, parquetUncompressed)
+		meta.i64(5, int64(len(players)))
+		meta.i64(6, size)
+		meta.i64(7, size)
+		meta.i64(9, offset)
+		chunk := &thriftEncoder{}
+		chunk.i64(2, offset)
+		chunk.child(3, meta)
+		chunks = append(chunks, chunk)
+	}
+	rowGroup := &thriftEncoder{}
+	rowGroup.structs(1, chunks...)
+	rowGroup.i64(2, total)
+	rowGroup.i64(3, int64(len(players)))
+
+	file := &thriftEncoder{}
+	file.i32(1, 1)
+	file.structs(2, schema...)
+	file.i64(3, int64(len(players)))
+	file.structs(4, rowGroup)
+	file.str(6, "mls_salaries")
+	footer := file.bytes()
+	out = append(out, footer...)
+	out = appendUint32(out, uint32(len(footer)))
+	out = append(out, parquetMagic...)
+	_, err := w.Write(out)
+	return err
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// parseReport writes the lines of a data file that were skipped or are missing a player field,
+// as a text table with a count of each reason, or as json
+func parseReport(w io.Writer, report *mlsdata.ParseReport, format string) error {
+	if format == "json" {
+		issues := report.Issues
+		if issues == nil {
+			issues = []mlsdata.ParseIssue{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(issues)
+	}
+	if len(report.Issues) == 0 {
+		_, err := fmt.Fprintln(w, "No parse issues found")
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "line\treason\ttext\n")
+	for _, issue := range report.Issues {
+		reason := issue.Reason
+		if issue.Skipped {
+			reason += " (skipped)"
+		}
+		fmt.Fprintf(t, "%d\t%s\t%s\n", issue.Line, reason, strings.TrimSpace(issue.Text))
+	}
+	counts := report.Counts()
+	reasons := make([]string, 0, len(counts))
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return counts[reasons[i]] > counts[reasons[j]] })
+	fmt.Fprintln(t)
+	for _, reason := range reasons {
+		fmt.Fprintf(t, "%d\t%s\n", counts[reason], reason)
+	}
+	return t.Flush()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// PosChange is a release in which a player's position group differs from their previous release
+type PosChange struct {
+	Name string
+	Data string
+	From string
+	To   string
+}
+
+// posChanges returns the position group changes of every player across releases
+func posChanges(releases []Release) []PosChange {
+	var changes []PosChange
+	last := make(map[string]string)
+	for _, r := range releases {
+		for _, p := range r.Players {
+			group := p.Pos.Group()
+			if group == "" {
+				continue
+			}
+			key := mlsdata.NameKey(p.Name)
+			if prev, ok := last[key]; ok && prev != group {
+				changes = append(changes, PosChange{Name: p.Name, Data: r.Data, From: prev, To: group})
+			}
+			last[key] = group
+		}
+	}
+	return changes
+}
+
+// posChangesReport writes the position group changes of players matching players, or all players if nil
+func posChangesReport(w io.Writer, players mlsdata.Players) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range posChanges(releases) {
+		if players != nil && !players.HasVal(c.Name) {
+			continue
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s -> %s\n", c.Data, c.Name, c.From, c.To)
+	}
+	return t.Flush()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// posGroupNames are the position groups -group accepts
+var posGroupNames = []string{"GK", "D", "M", "F"}
+
+// checkGroup returns group in upper case, or an error if it isn't a position group
+func checkGroup(group string) (string, error) {
+	upper := strings.ToUpper(group)
+	for _, g := range posGroupNames {
+		if upper == g {
+			return upper, nil
+		}
+	}
+	return "", fmt.Errorf("unknown position group %q, valid values: %s", group, strings.Join(posGroupNames, ", "))
+}
+
+// GroupSpend is a club's spend on the players of one position group
+type GroupSpend struct {
+	Club    string
+	Players int
+	Spend   mlsdata.Money
+	Share   float64
+}
+
+// groupSpends returns each club's spend on players in group, highest first. Share is the
+// fraction of the club's total in totals. Loaned players are left out unless countLoans is set.
+func groupSpends(players mlsdata.Players, group string, totals mlsdata.ClubTotals, countLoans bool) []GroupSpend {
+	byClub := make(map[string]*GroupSpend)
+	for _, p := range players {
+		if p.Pos.Group() != group || (p.OnLoan && !countLoans) {
+			continue
+		}
+		s, ok := byClub[p.Club]
+		if !ok {
+			s = &GroupSpend{Club: p.Club}
+			byClub[p.Club] = s
+		}
+		s.Players++
+		s.Spend += p.Compensation
+	}
+	spends := make([]GroupSpend, 0, len(byClub))
+	for _, s := range byClub {
+		if total := totals[s.Club]; total > 0 {
+			s.Share = s.Spend.Float() / total.Float()
+		}
+		spends = append(spends, *s)
+	}
+	sort.Slice(spends, func(i, j int) bool {
+		if spends[i].Spend != spends[j].Spend {
+			return spends[i].Spend > spends[j].Spend
+		}
+		return spends[i].Club < spends[j].Club
+	})
+	return spends
+}
+
+// groupReport writes the league table of club spend on the position group, marking clubs with
+// fewer than minPlayers players in the group
+func groupReport(w io.Writer, group string, players mlsdata.Players, totals mlsdata.ClubTotals, countLoans bool, minPlayers int) error {
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "\tclub\t%s spend\tplayers\tshare of payroll\n", group)
+	for i, s := range groupSpends(players, group, totals, countLoans) {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%d\t%.1f%%\t%s\n", i+1, s.Club, money(s.Spend), s.Players, s.Share*100, fewPlayers(s.Players, minPlayers))
+	}
+	return t.Flush()
+}
+
+// PosSpend is the spend on the players of one position
+type PosSpend struct {
+	Pos     string
+	Players int
+	Spend   mlsdata.Money
+}
+
+// posSpends returns the spend on each position, highest first. Players with several positions
+// are counted in their first.
+func posSpends(players mlsdata.Players) []PosSpend {
+	byPos := make(map[string]*PosSpend)
+	for _, p := range players {
+		pos := "none"
+		if len(p.Pos) > 0 {
+			pos = p.Pos[0]
+		}
+		s, ok := byPos[pos]
+		if !ok {
+			s = &PosSpend{Pos: pos}
+			byPos[pos] = s
+		}
+		s.Players++
+		s.Spend += p.Compensation
+	}
+	spends := make([]PosSpend, 0, len(byPos))
+	for _, s := range byPos {
+		spends = append(spends, *s)
+	}
+	sort.Slice(spends, func(i, j int) bool {
+		if spends[i].Spend != spends[j].Spend {
+			return spends[i].Spend > spends[j].Spend
+		}
+		return spends[i].Pos < spends[j].Pos
+	})
+	return spends
+}
+
+// posReport writes the spend, player count, and average compensation of each position, marking
+// averages of fewer than minPlayers players
+func posReport(w io.Writer, players mlsdata.Players, minPlayers int) error {
+	var total mlsdata.Money
+	for _, p := range players {
+		total += p.Compensation
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "pos\tplayers\tspend\taverage\tshare\n")
+	for _, s := range posSpends(players) {
+		share := 0.0
+		if total > 0 {
+			share = s.Spend.Float() / total.Float() * 100
+		}
+		fmt.Fprintf(t, "%s\t%d\t%s\t%s\t%.1f%%\t%s\n", s.Pos, s.Players, money(s.Spend), money(s.Spend.Div(s.Players)), share, fewPlayers(s.Players, minPlayers))
+	}
+	return t.Flush()
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// StarPremium is how much more the top decile of a position group is paid than its median
+type StarPremium struct {
+	Season  int
+	Group   string
+	Players int
+	Median  mlsdata.Money
+	Top10   mlsdata.Money // 90th percentile compensation
+	Premium float64       // Top10 / Median
+}
+
+// starPremiums returns the star premium of each position group in the latest release of each
+// season, in season then group order. Clubs not yet in the league are left out.
+func starPremiums(releases []Release) []StarPremium {
+	latest := make(map[int]Release)
+	for _, r := range releases {
+		latest[mlsdata.DataSeason(r.Data)] = r
+	}
+	var seasons []int
+	for season := range latest {
+		seasons = append(seasons, season)
+	}
+	sort.Ints(seasons)
+
+	var premiums []StarPremium
+	for _, season := range seasons {
+		comps := make(map[string][]mlsdata.Money)
+		for _, p := range latest[season].Players {
+			if mlsdata.InLeague(p.Club, season) {
+				g := p.Pos.Group()
+				comps[g] = append(comps[g], p.Compensation)
+			}
+		}
+		for _, g := range posGroupNames {
+			vals := sortedMoney(comps[g])
+			if len(vals) == 0 {
+				continue
+			}
+			sp := StarPremium{Season: season, Group: g, Players: len(vals),
+				Median: quantile(vals, 0.5), Top10: quantile(vals, 0.9)}
+			if sp.Median > 0 {
+				sp.Premium = sp.Top10.Float() / sp.Median.Float()
+			}
+			premiums = append(premiums, sp)
+		}
+	}
+	return premiums
+}
+
+// premiumReport writes the star premium of each position group in every season, followed by
+// each group's average premium across seasons. Groups of fewer than minPlayers players are
+// marked and left out of the averages.
+func premiumReport(w io.Writer, minPlayers int) error {
+	releases, err := loadReleases(0)
+	if err != nil {
+		return err
+	}
+	premiums := starPremiums(releases)
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "season\tgroup\tplayers\tmedian\ttop 10%%\tpremium\n")
+	sums := make(map[string]float64)
+	counts := make(map[string]int)
+	for _, sp := range premiums {
+		note := fewPlayers(sp.Players, minPlayers)
+		fmt.Fprintf(t, "%d\t%s\t%d\t%s\t%s\t%.1fx\t%s\n", sp.Season, sp.Group, sp.Players, money(sp.Median), money(sp.Top10), sp.Premium, note)
+		if note != "" {
+			continue
+		}
+		sums[sp.Group] += sp.Premium
+		counts[sp.Group]++
+	}
+	fmt.Fprintf(t, "\n")
+	for _, g := range posGroupNames {
+		if counts[g] > 0 {
+			fmt.Fprintf(t, "all\t%s\t\t\t\t%.1fx\n", g, sums[g]/float64(counts[g]))
+		}
+	}
+	return t.Flush()
+}
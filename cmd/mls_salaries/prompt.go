@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// groupPositions returns every position in the position group, so a -pos filter of them keeps
+// the players of the group
+func groupPositions(group string) mlsdata.Pos {
+	var positions mlsdata.Pos
+	for _, pos := range mlsdata.AllPos {
+		if mlsdata.PosGroup(pos) == group {
+			positions = append(positions, pos)
+		}
+	}
+	return positions
+}
+
+// prompt asks on w for the data file, clubs, and position group to show, reading the answers from
+// r, and sets data, clubs, and pos from them. A blank answer keeps the default; an invalid one is
+// asked again.
+func prompt(w io.Writer, r io.Reader, data *string, clubs *mlsdata.Clubs, pos *mlsdata.Pos) error {
+	files, err := dataFiles()
+	if err != nil {
+		return err
+	}
+	in := bufio.NewScanner(r)
+	ask := func(question string, answer func(string) error) error {
+		for {
+			fmt.Fprint(w, question)
+			if !in.Scan() {
+				if err := in.Err(); err != nil {
+					return err
+				}
+				return io.EOF
+			}
+			err := answer(strings.TrimSpace(in.Text()))
+			if err == nil {
+				return nil
+			}
+			fmt.Fprintln(w, err)
+		}
+	}
+
+	fmt.Fprintln(w, "data files:")
+	for i, name := range files {
+		fmt.Fprintf(w, "%3d  %s\n", i+1, name)
+	}
+	err = ask(fmt.Sprintf("data file number or name [%s]: ", *data), func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if n, err := strconv.Atoi(s); err == nil {
+			if n < 1 || n > len(files) {
+				return fmt.Errorf("choose a data file from 1 to %d", len(files))
+			}
+			*data = files[n-1]
+			return nil
+		}
+		for _, name := range files {
+			if s == name || s == strings.TrimSuffix(name, path.Ext(name)) || s+"_data" == name {
+				*data = name
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown data file %q", s)
+	})
+	if err != nil {
+		return err
+	}
+	err = ask("clubs, comma separated [all]: ", func(s string) error {
+		if s == "" {
+			return nil
+		}
+		return clubs.Set(s)
+	})
+	if err != nil {
+		return err
+	}
+	return ask("position group, GK, D, M, or F [all]: ", func(s string) error {
+		if s == "" {
+			return nil
+		}
+		group, err := checkGroup(s)
+		if err != nil {
+			return err
+		}
+		*pos = groupPositions(group)
+		return nil
+	})
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// sitePage is a release page of the published site
+type sitePage struct {
+	Data    string
+	Players mlsdata.Players
+	Payroll mlsdata.Money
+	Clubs   []mlsdata.ClubSummary
+}
+
+var siteFuncs = template.FuncMap{"money": money}
+
+const siteHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>MLS player salaries</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { padding: 0.2em 0.8em; text-align: left; }
+td.money { text-align: right; }
+tr:nth-child(even) { background: #f2f2f2; }
+</style>
+</head>
+<body>
+`
+
+var siteIndex = template.Must(template.New("index").Funcs(siteFuncs).Parse(siteHead + `<h1>MLS player salaries</h1>
+<p>MLS Players Association salary releases.</p>
+<table>
+<tr><th>release</th><th>players</th><th>league payroll</th><th>data</th></tr>
+{{range .}}<tr><td><a href="{{.Data}}.html">{{.Data}}</a></td><td>{{len .Players}}</td><td class="money">{{money .Payroll}}</td><td><a href="{{.Data}}.csv">csv</a> <a href="{{.Data}}.json">json</a></td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+var sitePageTmpl = template.Must(template.New("page").Funcs(siteFuncs).Parse(siteHead + `<p><a href="index.html">all releases</a></p>
+<h1>{{.Data}}</h1>
+<p>Download as <a href="{{.Data}}.csv">csv</a> or <a href="{{.Data}}.json">json</a>.</p>
+<h2>Clubs</h2>
+<table>
+<tr><th>club</th><th>players</th><th>payroll</th><th>median</th><th>top earner</th></tr>
+{{range .Clubs}}<tr><td>{{.Club}}</td><td>{{.Players}}</td><td class="money">{{money .Compensation}}</td><td class="money">{{money .Median}}</td><td>{{.Top.Name}}</td></tr>
+{{end}}</table>
+<h2>Players</h2>
+<table>
+<tr><th>club</th><th>pos</th><th>name</th><th>base salary</th><th>compensation</th></tr>
+{{range .Players}}<tr><td>{{.Club}}</td><td>{{.Pos.Label}}</td><td>{{.Name}}</td><td class="money">{{money .BaseSalary}}</td><td class="money">{{money .Compensation}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// publish writes a static site of every data file to dir: an index of releases, a page per
+// release, and csv and json files of each release's players. Players paid over threshold, if
+// set, are designated players.
+func publish(dir string, threshold mlsdata.Money) error {
+	releases, err := loadReleases(threshold)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	write := func(name string, render func(*bytes.Buffer) error) error {
+		buf := &bytes.Buffer{}
+		if err := render(buf); err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dir, name), buf.Bytes(), 0o644)
+	}
+
+	var pages []sitePage
+	for i := len(releases) - 1; i >= 0; i-- {
+		r := releases[i]
+		page := sitePage{Data: strings.TrimSuffix(canonicalName(r.Data), ".csv")}
+		season := mlsdata.DataSeason(r.Data)
+		for _, p := range r.Players {
+			// leave out clubs not yet in the league
+			if mlsdata.InLeague(p.Club, season) {
+				page.Players = append(page.Players, p)
+				page.Payroll += p.Compensation
+			}
+		}
+		sort.SliceStable(page.Players, func(i, j int) bool { return page.Players[i].Compensation > page.Players[j].Compensation })
+		for _, s := range mlsdata.Summarize(page.Players) {
+			page.Clubs = append(page.Clubs, s)
+		}
+		sort.Slice(page.Clubs, func(i, j int) bool { return page.Clubs[i].Compensation > page.Clubs[j].Compensation })
+		pages = append(pages, page)
+
+		if err := write(page.Data+".html", func(b *bytes.Buffer) error { return sitePageTmpl.Execute(b, page) }); err != nil {
+			return err
+		}
+		if err := write(page.Data+".csv", func(b *bytes.Buffer) error { return writeCSV(b, page.Players, false) }); err != nil {
+			return err
+		}
+		if err := write(page.Data+".json", func(b *bytes.Buffer) error { return writeJSON(b, page.Players, false) }); err != nil {
+			return err
+		}
+	}
+	return write("index.html", func(b *bytes.Buffer) error { return siteIndex.Execute(b, pages) })
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"fmt"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// ranks returns the payroll rank of each club in totals, starting at 1. The player pool and
+// players without a club aren't ranked.
+func ranks(totals mlsdata.ClubTotals) map[string]int {
+	r := make(map[string]int, len(totals))
+	for _, kv := range totals.Sort() {
+		if kv.Key != "" && kv.Key != "MLS" {
+			r[kv.Key] = len(r) + 1
+		}
+	}
+	return r
+}
+
+// rankMove returns the change in a club's rank since the previous release: "▲2", "▼1", "=",
+// "joined" for clubs in their first season, or "new"
+func rankMove(club string, rank int, prev map[string]int, season int) string {
+	was, ok := prev[club]
+	switch {
+	case !ok && mlsdata.ClubSeasons[club].First == season:
+		return "joined"
+	case !ok:
+		return "new"
+	case was > rank:
+		return fmt.Sprintf("▲%d", was-rank)
+	case was < rank:
+		return fmt.Sprintf("▼%d", rank-was)
+	default:
+		return "="
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import "mls_salaries/pkg/mlsdata"
+
+// Release is the list of players parsed from one data file
+type Release struct {
+	Data    string
+	Players mlsdata.Players
+}
+
+// overrides correct players in every data file read by loadData
+var overrides mlsdata.Overrides
+
+// loadData parses the named data file, classifying players with threshold as in classify
+func loadData(name string, threshold mlsdata.Money) (mlsdata.Players, error) {
+	f, err := openData(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	parser := mlsdata.NewFileParser(name, f)
+	parser.Overrides = overrides
+	players, err := parser.All()
+	classify(name, players, threshold)
+	return players, err
+}
+
+// classify sets the budget charge of players read from the named data file and returns the rules
+// used, with threshold, if set, as the DP threshold. Seasons without known rules are estimated
+// against the latest release of the closest season with rules.
+func classify(name string, players mlsdata.Players, threshold mlsdata.Money) mlsdata.Rules {
+	season := mlsdata.DataSeason(name)
+	rules, ok := mlsdata.RulesFor(season)
+	if !ok {
+		rules = estimateRules(season, players, rules)
+	}
+	rules.Threshold = threshold
+	for i := range players {
+		players[i].Charge = rules.Classify(players[i].Compensation)
+	}
+	return rules
+}
+
+// estimateRules estimates the rules of season from players and the latest release of the season
+// of known, falling back to known if that release can't be read
+func estimateRules(season int, players mlsdata.Players, known mlsdata.Rules) mlsdata.Rules {
+	files, err := dataFiles()
+	if err != nil {
+		return known
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		if mlsdata.DataSeason(files[i]) != known.Season {
+			continue
+		}
+		knownPlayers, err := loadData(files[i], 0)
+		if err != nil {
+			return known
+		}
+		return mlsdata.EstimateRules(season, players, known, knownPlayers)
+	}
+	return known
+}
+
+// loadReleases parses every embedded data file in release order, classifying players with
+// threshold as in classify
+func loadReleases(threshold mlsdata.Money) ([]Release, error) {
+	files, err := dataFiles()
+	if err != nil {
+		return nil, err
+	}
+	var releases []Release
+	for _, file := range files {
+		players, err := loadData(file, threshold)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, Release{Data: file, Players: players})
+	}
+	return releases, nil
+}
+
+// previousData returns the embedded data file released before name
+func previousData(name string) (string, bool) {
+	files, err := dataFiles()
+	if err != nil {
+		return "", false
+	}
+	for i, file := range files {
+		if file == name && i > 0 {
+			return files[i-1], true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// rulesTimeout bounds the fetch of -rules from a url, so a slow server doesn't hold up a report
+const rulesTimeout = 5 * time.Second
+
+// rulesCache returns the name of the cached copy of the rules fetched from url, named by a hash
+// of url so rules from different urls don't replace each other
+func rulesCache(url string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(dir, "mls_salaries", "rules-"+hex.EncodeToString(sum[:])+".json"), nil
+}
+
+// fetchRules returns the body of the rules json at url
+func fetchRules(url string) ([]byte, error) {
+	client := &http.Client{Timeout: rulesTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// loadRules replaces the built in roster rules with the rules json at src, a file or an http(s)
+// url. Rules fetched from a url are cached; if the url can't be fetched or its rules are invalid,
+// the cached copy is used, and failing that the built in rules are kept.
+func loadRules(src string) error {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		f, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		rules, err := mlsdata.ReadRules(f)
+		if err != nil {
+			return fmt.Errorf("%s: %v", src, err)
+		}
+		mlsdata.SeasonRules = rules
+		return nil
+	}
+
+	cache, cacheErr := rulesCache(src)
+	b, err := fetchRules(src)
+	if err == nil {
+		var rules []mlsdata.Rules
+		if rules, err = mlsdata.ReadRules(bytes.NewReader(b)); err == nil {
+			mlsdata.SeasonRules = rules
+			if cacheErr == nil {
+				// a failed write only costs the next run its fallback
+				_ = writeCache(cache, b)
+			}
+			return nil
+		}
+		err = fmt.Errorf("%s: %v", src, err)
+	}
+	log.Print(err)
+	if cacheErr == nil {
+		if f, err := os.Open(cache); err == nil {
+			defer f.Close()
+			if rules, err := mlsdata.ReadRules(f); err == nil {
+				log.Printf("using the rules cached in %s", cache)
+				mlsdata.SeasonRules = rules
+				return nil
+			}
+		}
+	}
+	log.Print("using the built in rules")
+	return nil
+}
+
+// describeRules returns the budget thresholds of rules
+func describeRules(rules mlsdata.Rules) string {
+	return fmt.Sprintf("salary budget %s, max budget charge %s, TAM max %s",
+		money(rules.SalaryBudget), money(rules.MaxBudgetCharge), money(rules.TAMMax))
+}
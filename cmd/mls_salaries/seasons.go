@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// LeagueSummary is the league-wide payroll of one data file
+type LeagueSummary struct {
+	Data    string
+	Players int
+	Total   mlsdata.Money
+	Average mlsdata.Money
+	Median  mlsdata.Money
+	DPs     int
+}
+
+// leagueSummary returns the league-wide payroll of a release, leaving out clubs not yet in the
+// league
+func leagueSummary(r Release) LeagueSummary {
+	s := LeagueSummary{Data: r.Data}
+	season := mlsdata.DataSeason(r.Data)
+	var comps []mlsdata.Money
+	for _, p := range r.Players {
+		if !mlsdata.InLeague(p.Club, season) {
+			continue
+		}
+		s.Players++
+		s.Total += p.Compensation
+		comps = append(comps, p.Compensation)
+		if p.Charge == mlsdata.ChargeDP {
+			s.DPs++
+		}
+	}
+	if s.Players > 0 {
+		s.Average = s.Total.Div(s.Players)
+	}
+	s.Median = quantile(sortedMoney(comps), 0.5)
+	return s
+}
+
+// seasonsReport writes the league-wide payroll of every data file, with the growth in total
+// payroll since the previous file. Players paid over threshold, if set, are designated players.
+func seasonsReport(w io.Writer, threshold mlsdata.Money) error {
+	releases, err := loadReleases(threshold)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "data\tplayers\ttotal\taverage\tmedian\tDPs\tgrowth\n")
+	var prev mlsdata.Money
+	for _, r := range releases {
+		s := leagueSummary(r)
+		growth := ""
+		if prev > 0 {
+			growth = fmt.Sprintf("%+.1f%%", (s.Total-prev).Float()/prev.Float()*100)
+		}
+		fmt.Fprintf(t, "%s\t%d\t%s\t%s\t%s\t%d\t%s\n", s.Data, s.Players, money(s.Total), money(s.Average), money(s.Median), s.DPs, growth)
+		prev = s.Total
+	}
+	return t.Flush()
+}
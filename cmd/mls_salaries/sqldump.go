@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// sqlSchema is the normalized schema written by writeSQL
+const sqlSchema = `CREATE TABLE clubs (
+	abv TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	first_season INTEGER,
+	last_season INTEGER
+);
+CREATE TABLE seasons (
+	id INTEGER PRIMARY KEY,
+	data TEXT NOT NULL UNIQUE,
+	season INTEGER NOT NULL
+);
+CREATE TABLE players (
+	id INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	name_key TEXT NOT NULL UNIQUE
+);
+CREATE TABLE salaries (
+	player_id INTEGER NOT NULL REFERENCES players(id),
+	season_id INTEGER NOT NULL REFERENCES seasons(id),
+	club TEXT REFERENCES clubs(abv),
+	pos TEXT,
+	base_salary REAL,
+	compensation REAL,
+	budget_charge TEXT
+);
+CREATE INDEX salaries_season ON salaries(season_id);
+CREATE INDEX salaries_player ON salaries(player_id);
+`
+
+// sqlQuote returns s as an SQL string literal, or NULL if s is empty
+func sqlQuote(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// sqlInt returns i as an SQL literal, or NULL if i is 0
+func sqlInt(i int) string {
+	if i == 0 {
+		return "NULL"
+	}
+	return fmt.Sprint(i)
+}
+
+// writeSQL writes an SQL script creating the normalized schema and loading every data file into
+// it, e.g. for "mls_salaries -sql | sqlite3 salaries.db". Players paid over threshold, if set,
+// are designated players.
+func writeSQL(w io.Writer, threshold mlsdata.Money) error {
+	releases, err := loadReleases(threshold)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "BEGIN TRANSACTION;\n", sqlSchema)
+
+	clubs := append([]mlsdata.Club(nil), mlsdata.Registry...)
+	sort.Slice(clubs, func(i, j int) bool { return clubs[i].ID < clubs[j].ID })
+	for _, c := range clubs {
+		fmt.Fprintf(bw, "INSERT INTO clubs VALUES (%s, %s, %s, %s);\n", sqlQuote(c.ID), sqlQuote(c.Name), sqlInt(c.Seasons.First), sqlInt(c.Seasons.Last))
+	}
+
+	ids := make(map[string]int)
+	for i, r := range releases {
+		fmt.Fprintf(bw, "INSERT INTO seasons VALUES (%d, %s, %d);\n", i+1, sqlQuote(r.Data), mlsdata.DataSeason(r.Data))
+		for _, p := range r.Players {
+			key := mlsdata.NameKey(p.Name)
+			id, ok := ids[key]
+			if !ok {
+				id = len(ids) + 1
+				ids[key] = id
+				fmt.Fprintf(bw, "INSERT INTO players VALUES (%d, %s, %s);\n", id, sqlQuote(p.Name), sqlQuote(key))
+			}
+			fmt.Fprintf(bw, "INSERT INTO salaries VALUES (%d, %d, %s, %s, %.2f, %.2f, %s);\n",
+				id, i+1, sqlQuote(p.Club), sqlQuote(p.Pos.Label()), p.BaseSalary.Float(), p.Compensation.Float(), sqlQuote(p.Charge))
+		}
+	}
+	fmt.Fprintln(bw, "COMMIT;")
+	return bw.Flush()
+}
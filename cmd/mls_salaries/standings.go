@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// Standings maps abbreviated club names to league points
+type Standings map[string]int
+
+// readClubValues reads a csv file of club,value records into a map keyed by abbreviated club name.
+// A header row is skipped.
+func readClubValues(name string) (map[string]float64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	values := make(map[string]float64)
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		val, err := strconv.ParseFloat(strings.Replace(strings.TrimSpace(record[1]), ",", "", -1), 64)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%s:%d: invalid value %q", name, line, record[1])
+		}
+		club, ok := mlsdata.LookupClub(record[0])
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: unknown club %q", name, line, record[0])
+		}
+		values[club.ID] = val
+	}
+	return values, nil
+}
+
+// readStandings reads a csv file of club,points records
+func readStandings(name string) (Standings, error) {
+	values, err := readClubValues(name)
+	if err != nil {
+		return nil, err
+	}
+	standings := make(Standings, len(values))
+	for club, points := range values {
+		standings[club] = int(points)
+	}
+	return standings, nil
+}
+
+// PointCost is a club's payroll per league point
+type PointCost struct {
+	Club     string
+	Payroll  mlsdata.Money
+	Points   int
+	PerPoint mlsdata.Money
+}
+
+// pointCosts returns the payroll per point of each club in standings, cheapest first.
+// Clubs without points are sorted last.
+func pointCosts(totals mlsdata.ClubTotals, standings Standings) []PointCost {
+	var costs []PointCost
+	for club, points := range standings {
+		c := PointCost{Club: club, Payroll: totals[club], Points: points}
+		if points > 0 {
+			c.PerPoint = c.Payroll.Div(points)
+		}
+		costs = append(costs, c)
+	}
+	sort.Slice(costs, func(i, j int) bool {
+		if (costs[i].Points == 0) != (costs[j].Points == 0) {
+			return costs[j].Points == 0
+		}
+		if costs[i].PerPoint != costs[j].PerPoint {
+			return costs[i].PerPoint < costs[j].PerPoint
+		}
+		return costs[i].Club < costs[j].Club
+	})
+	return costs
+}
+
+// pointsReport writes each club's payroll per league point using the standings file name
+func pointsReport(w io.Writer, name string, totals mlsdata.ClubTotals) error {
+	standings, err := readStandings(name)
+	if err != nil {
+		return err
+	}
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "\tclub\tpayroll\tpoints\tper point\n")
+	for i, c := range pointCosts(totals, standings) {
+		fmt.Fprintf(t, "%d\t%s\t%s\t%d\t%s\n", i+1, c.Club, money(c.Payroll), c.Points, money(c.PerPoint))
+	}
+	return t.Flush()
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// totalsStats are the statistics club totals can show
+var totalsStats = []string{"sum", "mean", "median"}
+
+// totalsSorts are the keys club totals can be sorted by
+var totalsSorts = []string{"total", "average", "median", "count", "delta"}
+
+// checkTotalsSort returns an error if key is not one of totalsSorts
+func checkTotalsSort(key string) error {
+	for _, s := range totalsSorts {
+		if s == key {
+			return nil
+		}
+	}
+	return fmt.Errorf("valid totals sorts: %s", strings.Join(totalsSorts, ", "))
+}
+
+// sortTotals sorts club totals by key, highest first. total is the value of each total, delta is
+// the change in it since prev, and the other keys are from the club's summary. Clubs with no
+// total in prev have no delta and sort last, keeping their order.
+func sortTotals(totals []mlsdata.KeyValue, key string, summaries map[string]mlsdata.ClubSummary, prev mlsdata.ClubTotals) {
+	if key == "delta" {
+		sort.SliceStable(totals, func(i, j int) bool {
+			was, ok := prev[totals[i].Key]
+			wasJ, okJ := prev[totals[j].Key]
+			if ok != okJ {
+				return ok
+			}
+			return ok && totals[i].Value-was > totals[j].Value-wasJ
+		})
+		return
+	}
+	if key == "count" {
+		sort.SliceStable(totals, func(i, j int) bool {
+			return summaries[totals[i].Key].Players > summaries[totals[j].Key].Players
+		})
+		return
+	}
+	metric := func(kv mlsdata.KeyValue) mlsdata.Money {
+		switch key {
+		case "average":
+			return summaries[kv.Key].Average
+		case "median":
+			return summaries[kv.Key].Median
+		default:
+			return kv.Value
+		}
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return metric(totals[i]) > metric(totals[j]) })
+}
+
+// fewPlayers returns a note marking a mean or median of n players as unreliable if n is under
+// min, or "" if it isn't
+func fewPlayers(n, min int) string {
+	if n < min {
+		return fmt.Sprintf("only %d players", n)
+	}
+	return ""
+}
+
+// mean returns the average of vals
+func mean(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range vals {
+		sum += v
+	}
+	return sum / float64(len(vals))
+}
+
+// sum returns the total of vals
+func sum(vals []mlsdata.Money) mlsdata.Money {
+	var total mlsdata.Money
+	for _, v := range vals {
+		total += v
+	}
+	return total
+}
+
+// median returns the middle value of vals
+func median(vals []mlsdata.Money) mlsdata.Money {
+	if len(vals) == 0 {
+		return 0
+	}
+	sorted := sortedMoney(vals)
+	half := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[half-1] + sorted[half]).Div(2)
+	}
+	return sorted[half]
+}
+
+// sortedMoney returns a sorted copy of vals
+func sortedMoney(vals []mlsdata.Money) []mlsdata.Money {
+	sorted := append([]mlsdata.Money(nil), vals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// checkStat returns an error if stat is not one of totalsStats
+func checkStat(stat string) error {
+	for _, s := range totalsStats {
+		if s == stat {
+			return nil
+		}
+	}
+	return fmt.Errorf("valid totals: %s", strings.Join(totalsStats, ", "))
+}
+
+// aggregate returns the sum, mean, or median of each club's compensation values
+func aggregate(comps map[string][]mlsdata.Money, stat string) (mlsdata.ClubTotals, error) {
+	if err := checkStat(stat); err != nil {
+		return nil, err
+	}
+	totals := make(mlsdata.ClubTotals, len(comps))
+	for club, vals := range comps {
+		switch stat {
+		case "sum":
+			totals[club] = sum(vals)
+		case "mean":
+			totals[club] = sum(vals).Div(len(vals))
+		case "median":
+			totals[club] = median(vals)
+		}
+	}
+	return totals, nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// summaryQuantiles are the percentiles shown by summaryReport
+var summaryQuantiles = []float64{0.25, 0.5, 0.75, 0.9}
+
+// quantile returns the q quantile of sorted vals, interpolating between the closest values
+func quantile(sorted []mlsdata.Money, q float64) mlsdata.Money {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	return sorted[lo] + (sorted[lo+1] - sorted[lo]).Scale(pos-float64(lo))
+}
+
+// stddev returns the population standard deviation of vals
+func stddev(vals []float64) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	m := mean(vals)
+	var sum float64
+	for _, v := range vals {
+		sum += (v - m) * (v - m)
+	}
+	return math.Sqrt(sum / float64(len(vals)))
+}
+
+// summaryReport writes the distribution of each club's compensation values, and of the league.
+// Clubs of fewer than minPlayers players are marked.
+func summaryReport(w io.Writer, comps map[string][]mlsdata.Money, minPlayers int) error {
+	clubs := make([]string, 0, len(comps))
+	var league []mlsdata.Money
+	for club, vals := range comps {
+		clubs = append(clubs, club)
+		league = append(league, vals...)
+	}
+	sort.Strings(clubs)
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "club\tplayers\tmean\tstd dev\t25%%\tmedian\t75%%\t90%%\n")
+	row := func(name string, vals []mlsdata.Money) {
+		sorted := sortedMoney(vals)
+		floats := make([]float64, len(sorted))
+		for i, v := range sorted {
+			floats[i] = v.Float()
+		}
+		fmt.Fprintf(t, "%s\t%d\t%s\t%s", name, len(sorted), money(sum(sorted).Div(len(sorted))), money(mlsdata.Dollars(stddev(floats))))
+		for _, q := range summaryQuantiles {
+			fmt.Fprintf(t, "\t%s", money(quantile(sorted, q)))
+		}
+		fmt.Fprintf(t, "\t%s\n", fewPlayers(len(sorted), minPlayers))
+	}
+	for _, club := range clubs {
+		row(club, comps[club])
+	}
+	row("league", league)
+	return t.Flush()
+}
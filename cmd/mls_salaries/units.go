@@ -0,0 +1,26 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// unit is the -unit money values are shown in
+var unit = "full"
+
+// checkUnit returns an error if u is not one of mlsdata.Units
+func checkUnit(u string) error {
+	for _, valid := range mlsdata.Units {
+		if u == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("valid units: %s", strings.Join(mlsdata.Units, ", "))
+}
+
+// money returns v formatted in unit
+func money(v mlsdata.Money) string {
+	return mlsdata.FormatUnit(v, unit)
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// waterfallWidth is the width of the longest text waterfall bar
+const waterfallWidth = 50
+
+// waterfallLabels are the step labels of each change kind
+var waterfallLabels = []string{"raises", "cuts", "new signings", "departures"}
+
+// WaterfallStep is one step of a club's wage bill change between two releases. The start and
+// end steps are totals; the others are changes.
+type WaterfallStep struct {
+	Label  string
+	Amount mlsdata.Money
+	Total  mlsdata.Money // wage bill after the step
+	IsSum  bool
+}
+
+// waterfall returns the steps from the wage bill of from to the wage bill of to: new signings,
+// raises, departures, and cuts
+func waterfall(from, to mlsdata.Players) []WaterfallStep {
+	var start, end mlsdata.Money
+	for _, p := range from {
+		start += p.Compensation
+	}
+	for _, p := range to {
+		end += p.Compensation
+	}
+	amounts := make([]mlsdata.Money, len(changeNames))
+	for _, c := range salaryChanges(from, to) {
+		amounts[c.Kind] += c.Delta
+	}
+	steps := []WaterfallStep{{Label: "start", Amount: start, Total: start, IsSum: true}}
+	total := start
+	for _, kind := range []int{changeNew, changeRaise, changeGone, changeCut} {
+		total += amounts[kind]
+		steps = append(steps, WaterfallStep{Label: waterfallLabels[kind], Amount: amounts[kind], Total: total})
+	}
+	return append(steps, WaterfallStep{Label: "end", Amount: end, Total: end, IsSum: true})
+}
+
+// waterfallReport writes the wage bill change of club between the data files from and to, as a
+// text table and bar chart, or as an SVG chart fragment if format is html
+func waterfallReport(w io.Writer, club, from, to, format string, keep func(mlsdata.Player) bool, threshold mlsdata.Money, adjust bool) error {
+	releases, err := compareReleases(from, to, func(p mlsdata.Player) bool { return p.Club == club && keep(p) }, threshold, adjust)
+	if err != nil {
+		return err
+	}
+	if len(releases[0]) == 0 && len(releases[1]) == 0 {
+		return fmt.Errorf("no %s players in %s or %s", club, from, to)
+	}
+	steps := waterfall(releases[0], releases[1])
+	var max float64
+	for _, s := range steps {
+		max = math.Max(max, s.Total.Float())
+	}
+	// bar returns the start and end of a step's bar, scaled so max is width
+	bar := func(i int, width float64) (float64, float64) {
+		s := steps[i]
+		if s.IsSum || max == 0 {
+			return 0, s.Total.Float() / math.Max(max, 1) * width
+		}
+		before, after := steps[i-1].Total.Float(), s.Total.Float()
+		return math.Min(before, after) / max * width, math.Max(before, after) / max * width
+	}
+	if format == "html" {
+		return waterfallSVG(w, club, from, to, steps, bar)
+	}
+
+	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(t, "%s\tchange\ttotal\n", club)
+	for i, s := range steps {
+		change := ""
+		if !s.IsSum {
+			sign := "+"
+			if s.Amount < 0 {
+				sign = "-"
+			}
+			change = sign + money(s.Amount.Abs())
+		}
+		lo, hi := bar(i, waterfallWidth)
+		mark := "#"
+		switch {
+		case s.IsSum:
+		case s.Amount < 0:
+			mark = "-"
+		default:
+			mark = "+"
+		}
+		fmt.Fprintf(t, "%s\t%s\t%s\t%s%s\n", s.Label, change, money(s.Total),
+			strings.Repeat(" ", int(math.Round(lo))), strings.Repeat(mark, int(math.Round(hi-lo))))
+	}
+	if adjust {
+		fmt.Fprintf(t, "\nin %d dollars\n", mlsdata.CPISeason())
+	}
+	return t.Flush()
+}
+
+// waterfallSVG writes the waterfall steps as an SVG chart fragment
+func waterfallSVG(w io.Writer, club, from, to string, steps []WaterfallStep, bar func(int, float64) (float64, float64)) error {
+	const labelWidth, chartWidth, rowHeight = 140, 460, 32
+	height := rowHeight * (len(steps) + 1)
+	fmt.Fprintf(w, `<svg class="waterfall" xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="sans-serif" font-size="13">`+"\n",
+		labelWidth+chartWidth+120, height)
+	fmt.Fprintf(w, `<text x="0" y="18" font-weight="bold">%s: %s to %s</text>`+"\n", html.EscapeString(club), html.EscapeString(from), html.EscapeString(to))
+	for i, s := range steps {
+		y := rowHeight * (i + 1)
+		lo, hi := bar(i, chartWidth)
+		color := "#2b6b9f"
+		switch {
+		case s.IsSum:
+		case s.Amount < 0:
+			color = "#b83232"
+		default:
+			color = "#3a9c5c"
+		}
+		value := money(s.Total)
+		if !s.IsSum {
+			value = money(s.Amount)
+		}
+		fmt.Fprintf(w, `<text x="0" y="%d">%s</text>`+"\n", y+18, html.EscapeString(s.Label))
+		fmt.Fprintf(w, `<rect x="%.1f" y="%d" width="%.1f" height="%d" fill="%s"/>`+"\n", labelWidth+lo, y+4, math.Max(hi-lo, 1), rowHeight-8, color)
+		fmt.Fprintf(w, `<text x="%.1f" y="%d">%s</text>`+"\n", labelWidth+hi+6, y+18, value)
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
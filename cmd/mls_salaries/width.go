@@ -0,0 +1,28 @@
+package main
+
+import "mls_salaries/pkg/mlsdata"
+
+// truncate returns s cut to at most n runes, ending in "…" if it was cut. An n of 0 or less
+// leaves s as is.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if n <= 0 || len(r) <= n {
+		return s
+	}
+	if n == 1 {
+		return "…"
+	}
+	return string(r[:n-1]) + "…"
+}
+
+// moneyWidth returns the width of the widest of vals formatted by money, used to right align
+// a money column
+func moneyWidth(vals []mlsdata.Money) int {
+	width := 0
+	for _, v := range vals {
+		if n := len(money(v)); n > width {
+			width = n
+		}
+	}
+	return width
+}
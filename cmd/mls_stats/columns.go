@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// statsColumns maps the fields read from a stats table to the column titles used for them in
+// ASA exports, matched ignoring case. Compensation is in thousands of dollars.
+var statsColumns = map[string][]string{
+	"player":  {"Player", "Name"},
+	"team":    {"Team", "Club"},
+	"minutes": {"Min", "Minutes"},
+	"pos":     {"Pos", "Position"},
+	"goals":   {"G", "Goals"},
+	"assists": {"A", "Assists"},
+	"comp":    {"Comp ($K)", "Guaranteed Compensation ($K)"},
+}
+
+// readColumnMap reads a csv file of field,column title records and adds the titles to
+// statsColumns, ahead of the built in titles
+func readColumnMap(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		field := strings.ToLower(strings.TrimSpace(record[0]))
+		titles, ok := statsColumns[field]
+		if !ok {
+			return fmt.Errorf("%s:%d: unknown field %q, valid fields: %s", name, line, record[0], strings.Join(statsFields(), ", "))
+		}
+		statsColumns[field] = append([]string{strings.TrimSpace(record[1])}, titles...)
+	}
+}
+
+// statsFields returns the fields of statsColumns, sorted
+func statsFields() []string {
+	fields := make([]string, 0, len(statsColumns))
+	for field := range statsColumns {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// columnIndexes returns the column index of each field of statsColumns in the header row, or an
+// error naming the fields without a column
+func columnIndexes(header []string) (map[string]int, error) {
+	cols := make(map[string]int, len(statsColumns))
+	var missing []string
+	for _, field := range statsFields() {
+	titles:
+		for _, title := range statsColumns[field] {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), title) {
+					cols[field] = i
+					break titles
+				}
+			}
+		}
+		if _, ok := cols[field]; !ok {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("no column for %s in the stats table header", strings.Join(missing, ", "))
+	}
+	return cols, nil
+}
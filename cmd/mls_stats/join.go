@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"mls_salaries/pkg/mlsdata"
+)
+
+// readSalaries reads the players of an MLSPA salary data file, and a hash of its contents for
+// the join cache key
+func readSalaries(name string) (mlsdata.Players, string, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, "", err
+	}
+	players, err := mlsdata.NewFileParser(name, bytes.NewReader(b)).All()
+	sum := sha256.Sum256(b)
+	return players, hex.EncodeToString(sum[:]), err
+}
+
+// joinVersion is part of every join cache key; change it when matchSalary changes
+const joinVersion = "1"
+
+// joinKey returns the join cache key of the salary data file and stats table with contents
+// hashes salarySum and statsSum, and the stats table columns cols
+func joinKey(salarySum, statsSum string, cols map[string]int) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, joinVersion+"\x00"+salarySum+"\x00"+statsSum+"\x00")
+	if err := json.NewEncoder(h).Encode(cols); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// joinCache returns the name of the cache of the stats players matched to a salary data file,
+// for join cache key
+func joinCache(key string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mls_salaries", "join-"+key+".json"), nil
+}
+
+// readJoinCache returns the cached matches for join cache key, or no matches if there is no
+// cache
+func readJoinCache(key string) map[string]int {
+	matches := make(map[string]int)
+	name, err := joinCache(key)
+	if err != nil {
+		return matches
+	}
+	if b, err := os.ReadFile(name); err == nil {
+		// a corrupt cache is matched again from scratch
+		if json.Unmarshal(b, &matches) != nil {
+			matches = make(map[string]int)
+		}
+	}
+	return matches
+}
+
+// writeJoinCache writes the matches for join cache key through a temporary file, so readers
+// never see a partial cache
+func writeJoinCache(key string, matches map[string]int) error {
+	name, err := joinCache(key)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(matches)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(name), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), name)
+}
+
+// matchKey is the key of a stats player in the join cache
+func matchKey(p Player) string {
+	return p.Club + "\t" + p.Name
+}
+
+// matchSalary returns the index of the player in salaries matching p: the player with the same
+// name key, or else the only fuzzy name match at p's club, or else the only fuzzy name match in
+// the league
+func matchSalary(p Player, salaries mlsdata.Players, byKey map[string]int) (int, bool) {
+	if i, ok := byKey[mlsdata.NameKey(p.Name)]; ok {
+		return i, true
+	}
+	var atClub, inLeague []int
+	for i, s := range salaries {
+		if !mlsdata.MatchName(s.Name, p.Name) {
+			continue
+		}
+		inLeague = append(inLeague, i)
+		if s.Club == p.Club {
+			atClub = append(atClub, i)
+		}
+	}
+	switch {
+	case len(atClub) == 1:
+		return atClub[0], true
+	case len(atClub) == 0 && len(inLeague) == 1:
+		return inLeague[0], true
+	}
+	return 0, false
+}
+
+// joinSalaries replaces the compensation of each player with their guaranteed compensation in
+// salaries, and returns the players without a match, who keep the stats table compensation.
+// matches holds the index in salaries of each player already matched, or -1 if they have no
+// match; players not in it are matched and added, so fuzzy matching is only run once per player.
+func joinSalaries(players []Player, salaries mlsdata.Players, matches map[string]int) []Player {
+	var byKey map[string]int
+	var unmatched []Player
+	for i := range players {
+		key := matchKey(players[i])
+		j, ok := matches[key]
+		if !ok || j >= len(salaries) {
+			if byKey == nil {
+				byKey = make(map[string]int, len(salaries))
+				for j, s := range salaries {
+					byKey[mlsdata.NameKey(s.Name)] = j
+				}
+			}
+			if j, ok = matchSalary(players[i], salaries, byKey); !ok {
+				j = -1
+			}
+			matches[key] = j
+		}
+		if j < 0 {
+			unmatched = append(unmatched, players[i])
+			continue
+		}
+		players[i].Compensation = salaries[j].Compensation
+		players[i].BaseSalary = salaries[j].BaseSalary
+	}
+	return unmatched
+}
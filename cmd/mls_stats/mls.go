@@ -1,42 +1,64 @@
 package main
 
 import (
-	"bytes"
+	"crypto/sha256"
 	"embed"
 	"encoding/csv"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"mls_salaries/pkg/mlsdata"
 )
 
-// Player is an MLS player
+// Player is an MLS player with goal, assist, and minutes played stats
 type Player struct {
-	Club         string
-	Name         string
-	Pos          string
-	Goals        int
-	Assists      int
-	Compensation float64
-	GAPerDollar  float64
+	mlsdata.Player
+	Goals   int
+	Assists int
+	Minutes int
+	// Cost is the player's compensation per unit of the -per metric
+	Cost float64
+}
+
+// metrics are the denominators of the dollars per metric -per accepts, with their descriptions
+var metrics = map[string]string{
+	"ga":     "goals+assists",
+	"minute": "minute played",
+	"90":     "90 minutes played",
+}
+
+// denominator returns the amount of metric the player produced
+func (p Player) denominator(metric string) float64 {
+	switch metric {
+	case "minute":
+		return float64(p.Minutes)
+	case "90":
+		return float64(p.Minutes) / 90
+	default:
+		return float64(p.Goals + p.Assists)
+	}
 }
 
+// Clubs is a list of club IDs from the mlsdata club registry
 type Clubs []string
 
 func (c *Clubs) Set(v string) error {
 	clubs := strings.Split(v, ",")
-	for _, club := range clubs {
-		club = strings.ToUpper(strings.TrimSpace(club))
-		if allClubs.Has(club) {
-			*c = append(*c, club)
-		} else {
-			return fmt.Errorf("valid clubs: %s", allClubs)
+	for _, name := range clubs {
+		club, ok := mlsdata.LookupClub(name)
+		if !ok {
+			return fmt.Errorf("valid clubs: %s", strings.Join(mlsdata.ClubIDs(), ", "))
 		}
+		*c = append(*c, club.ID)
 	}
 	return nil
 }
@@ -55,54 +77,55 @@ func (c *Clubs) String() string {
 	return strings.Join(*c, ", ")
 }
 
-var allClubs = Clubs{
-	"COL",
-	"LAG",
-	"MIN",
-	"ORL",
-	"CLB",
-	"PHI",
-	"CHI",
-	"HOU",
-	"FCD",
-	"POR",
-	"RSL",
-	"CIN",
-	"SJE",
-	"SKC",
-	"VAN",
-	"NER",
-	"DCU",
-	"ATL",
-	"LAFC",
-	"TOR",
-	"NYC",
-	"NYRB",
-	"SEA",
-	"MTL",
-}
-
 //go:embed ASAshootertable.csv
 var dataFS embed.FS
 
 func main() {
 	var (
-		r       *csv.Reader
-		players []Player
-		clubs   = &Clubs{}
+		r          *csv.Reader
+		players    []Player
+		clubs      = &Clubs{}
+		minPlayers = flag.Int("min-players", 5, "flag positions with fewer players and leave them out of the 2x flag")
+		per        = flag.String("per", "ga", "denominator of dollars per metric: ga (goals+assists), minute, or 90 (per 90 minutes)")
+		statsFile  = flag.String("stats", "", "ASA shooter table csv file to read instead of the built in 2019 table")
+		columnMap  = flag.String("columns", "", "csv file of field,column title records naming the stats table columns of player, team, minutes, pos, goals, assists, and comp")
+		salaryFile = flag.String("salaries", "", "MLSPA salary data file to take guaranteed compensation from instead of the stats table")
+		minMinutes = flag.Int("min-minutes", 0, "leave out players with fewer minutes played")
+		noCache    = flag.Bool("no-cache", false, "don't use or update the cache of players matched to -salaries")
 	)
 
 	flag.Var(clubs, "clubs", "comma separated list of clubs")
 	flag.Parse()
+	if _, ok := metrics[*per]; !ok {
+		log.Fatalf("unknown -per metric %q, valid values: ga, minute, 90", *per)
+	}
 
-	f, err := dataFS.Open("ASAshootertable.csv")
+	if *columnMap != "" {
+		check(readColumnMap(*columnMap))
+	}
+	var f io.ReadCloser
+	var err error
+	if *statsFile != "" {
+		f, err = os.Open(*statsFile)
+	} else {
+		f, err = dataFS.Open("ASAshootertable.csv")
+	}
 	check(err)
-	r = csv.NewReader(f)
-	_, err = r.Read()
-	//for i, title := range titles {
-	//	fmt.Printf("%d: %s\n", i, title)
-	//}
+	defer f.Close()
+	// the stats table is hashed as it's read, for the join cache key
+	statsHash := sha256.New()
+	r = csv.NewReader(io.TeeReader(f, statsHash))
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
 	check(err)
+	cols, err := columnIndexes(header)
+	check(err)
+	field := func(record []string, name string) string {
+		if i := cols[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
 	for {
 		record, err := r.Read()
 		if err == io.EOF {
@@ -110,102 +133,164 @@ func main() {
 		}
 		check(err)
 
+		// the shooter table uses its own club codes
+		club := field(record, "team")
+		if c, ok := mlsdata.LookupClub(club); ok {
+			club = c.ID
+		}
 		if len(*clubs) != 0 {
-			if !clubs.Has(record[3]) {
+			if !clubs.Has(club) {
 				continue
 			}
 		}
-		comp, err := strconv.ParseFloat(record[27], 32)
+		comp, err := strconv.ParseFloat(field(record, "comp"), 32)
 		if err != nil {
 			comp = 0
 		}
-		comp = comp * 1000
-		goals, err := strconv.Atoi(record[11])
+		goals, err := strconv.Atoi(field(record, "goals"))
 		if err != nil {
 			goals = 0
 		}
-		assists, err := strconv.Atoi(record[17])
+		assists, err := strconv.Atoi(field(record, "assists"))
 		if err != nil {
 			assists = 0
 		}
-		/*
-			0: First 1: Last 2: Player 3: Team 4: Season 5: Min 6: Pos 7: Shots 8: SoT 9: Dist 10: Solo 11: G 12: xG
-			13: xPlace 14: G-xG 15: KeyP 16: Dist.key 17: A 18: xA 19: A-xA 20: xG+xA 21: PA 22: xPA 23: xG/shot
-			24: xA/pass 25: G-xG/shot 26: A-xA/pass 27: Comp ($K) 28: Team/96 29: Min/96 30: Pos/96 31: Shots/96
-			32: SoT/96 33: G/96 34: xG/96 35: xPlace/96 36: G-xG/96 37: KeyP/96 38: A/96 39: xA/96 40: A-xA/96
-			41: xG+xA/96 42: PA/96 43: xPA/96 44: Comp ($K)/96 45: extreme1 46: extreme2 47: plotnames
+		minutes, err := strconv.Atoi(field(record, "minutes"))
+		if err != nil {
+			minutes = 0
+		}
+		if minutes < *minMinutes {
+			continue
+		}
+		/* the built in table's columns:
+		0: First 1: Last 2: Player 3: Team 4: Season 5: Min 6: Pos 7: Shots 8: SoT 9: Dist 10: Solo 11: G 12: xG
+		13: xPlace 14: G-xG 15: KeyP 16: Dist.key 17: A 18: xA 19: A-xA 20: xG+xA 21: PA 22: xPA 23: xG/shot
+		24: xA/pass 25: G-xG/shot 26: A-xA/pass 27: Comp ($K) 28: Team/96 29: Min/96 30: Pos/96 31: Shots/96
+		32: SoT/96 33: G/96 34: xG/96 35: xPlace/96 36: G-xG/96 37: KeyP/96 38: A/96 39: xA/96 40: A-xA/96
+		41: xG+xA/96 42: PA/96 43: xPA/96 44: Comp ($K)/96 45: extreme1 46: extreme2 47: plotnames
 		*/
 		p := Player{
-			Club:         record[3],
-			Name:         record[2],
-			Pos:          record[6],
-			Goals:        goals,
-			Assists:      assists,
-			Compensation: comp,
-			GAPerDollar:  comp / float64(goals+assists),
+			Player: mlsdata.Player{
+				Club:         club,
+				Name:         field(record, "player"),
+				Pos:          mlsdata.Pos{field(record, "pos")},
+				Compensation: mlsdata.Dollars(comp * 1000),
+			},
+			Goals:   goals,
+			Assists: assists,
+			Minutes: minutes,
 		}
 		players = append(players, p)
 	}
 
+	var unmatched []Player
+	if *salaryFile != "" {
+		salaries, sum, err := readSalaries(*salaryFile)
+		check(err)
+		key, err := joinKey(sum, hex.EncodeToString(statsHash.Sum(nil)), cols)
+		check(err)
+		matches := make(map[string]int)
+		if !*noCache {
+			matches = readJoinCache(key)
+		}
+		cached := len(matches)
+		unmatched = joinSalaries(players, salaries, matches)
+		if !*noCache && len(matches) > cached {
+			// a failed write only costs the next run its cached matches
+			_ = writeJoinCache(key, matches)
+		}
+	}
+	for i := range players {
+		players[i].Cost = players[i].Compensation.Float() / players[i].denominator(*per)
+	}
+
 	dollars := []float64{}
-	var median float64
+	byPos := make(map[string][]float64)
 	for _, p := range players {
-		if p.GAPerDollar > 0 && p.Pos != "CDM" && p.Pos != "CB" && p.Pos != "GK" {
-			dollars = append(dollars, p.GAPerDollar)
+		if p.Cost > 0 && !math.IsInf(p.Cost, 1) {
+			byPos[p.Pos.Label()] = append(byPos[p.Pos.Label()], p.Cost)
+			if !p.Pos.HasAny(mlsdata.Pos{"CDM", "CB", "GK"}) {
+				dollars = append(dollars, p.Cost)
+			}
 		}
 	}
 	sort.Float64s(dollars)
-	half := len(dollars) / 2
-	if len(dollars)%2 != 0 {
-		// odd
-		median = (dollars[half-1] + dollars[half]) / 2
-	} else if half != 0 {
-		median = dollars[half]
-	}
-	fmt.Println("median dollars per goals+assists:", commaf(median))
+	fmt.Printf("median dollars per %s: %s\n", metrics[*per], mlsdata.Commaf(median(dollars)))
+
+	var positions []string
+	for pos := range byPos {
+		positions = append(positions, pos)
+	}
+	sort.Strings(positions)
+	posMedian := make(map[string]float64, len(byPos))
+	t := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, err = fmt.Fprintf(t, "\npos\tplayers\t25%%\tmedian\t75%%\n")
+	check(err)
+	for _, pos := range positions {
+		q1, q2, q3 := quartiles(byPos[pos])
+		few := ""
+		if len(byPos[pos]) < *minPlayers {
+			few = "\ttoo few players"
+		} else {
+			posMedian[pos] = q2
+		}
+		_, err := fmt.Fprintf(t, "%s\t%d\t%s\t%s\t%s%s\n", pos, len(byPos[pos]), mlsdata.Commaf(q1), mlsdata.Commaf(q2), mlsdata.Commaf(q3), few)
+		check(err)
+	}
+	check(t.Flush())
+	fmt.Println()
+
 	sort.Slice(players, func(i, j int) bool { return players[i].Compensation > players[j].Compensation })
 	sort.SliceStable(players, func(i, j int) bool { return players[i].Goals+players[i].Assists > players[j].Goals+players[j].Assists })
 	sort.SliceStable(players, func(i, j int) bool {
-		return players[i].GAPerDollar < players[j].GAPerDollar
+		return players[i].Cost < players[j].Cost
 	})
 
 	w := os.Stdout
-	t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	t = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
 	for i, data := range players {
-		_, err := fmt.Fprintf(t, "%d\t%s\t%s\t%d/%d\t%s\t%s\t(%s)\n", i, data.Club, data.Pos, data.Goals, data.Assists, data.Name, commaf(data.Compensation), commaf(data.GAPerDollar))
+		// flag players paying more than twice their position's median per unit of the metric
+		flag := ""
+		if m, ok := posMedian[data.Pos.Label()]; ok && data.Cost > 2*m {
+			flag = "\t2x"
+		}
+		_, err := fmt.Fprintf(t, "%d\t%s\t%s\t%d/%d\t%d'\t%s\t%s\t(%s)%s\n", i, data.Club, data.Pos.Label(), data.Goals, data.Assists, data.Minutes, data.Name, data.Compensation, mlsdata.Commaf(data.Cost), flag)
 		check(err)
 	}
 	check(t.Flush())
-}
 
-// commaf returns v as a string with commas added
-func commaf(v float64) string {
-	buf := &bytes.Buffer{}
-	if v < 0 {
-		buf.Write([]byte{'-'})
-		v = 0 - v
+	if *salaryFile != "" {
+		fmt.Printf("\nno salary data for %d of %d players, using the stats table compensation:\n", len(unmatched), len(players))
+		t = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		for _, p := range unmatched {
+			_, err := fmt.Fprintf(t, "  %s\t%s\n", p.Club, p.Name)
+			check(err)
+		}
+		check(t.Flush())
 	}
+}
 
-	comma := []byte{','}
-
-	parts := strings.Split(strconv.FormatFloat(v, 'f', 2, 64), ".")
-	pos := 0
-	if len(parts[0])%3 != 0 {
-		pos += len(parts[0]) % 3
-		buf.WriteString(parts[0][:pos])
-		buf.Write(comma)
-	}
-	for ; pos < len(parts[0]); pos += 3 {
-		buf.WriteString(parts[0][pos : pos+3])
-		buf.Write(comma)
+// median returns the middle value of sorted vals
+func median(vals []float64) float64 {
+	half := len(vals) / 2
+	switch {
+	case len(vals) == 0:
+		return 0
+	case len(vals)%2 == 0:
+		return (vals[half-1] + vals[half]) / 2
+	default:
+		return vals[half]
 	}
-	buf.Truncate(buf.Len() - 1)
+}
 
-	if len(parts) > 1 {
-		buf.Write([]byte{'.'})
-		buf.WriteString(parts[1])
-	}
-	return buf.String()
+// quartiles sorts vals and returns their first quartile, median, and third quartile
+func quartiles(vals []float64) (q1, q2, q3 float64) {
+	sort.Float64s(vals)
+	half := len(vals) / 2
+	q2 = median(vals)
+	q1 = median(vals[:half])
+	q3 = median(vals[len(vals)-half:])
+	return q1, q2, q3
 }
 
 func check(err error) {
@@ -0,0 +1,312 @@
+package mlsdata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Clubs is a map of MLS club names to abbreviated names
+type Clubs map[string]string
+
+// Conferences
+const (
+	East = "East"
+	West = "West"
+)
+
+// ClubAlias is a name a club went by, from season First through Last. A Last of 0 means the
+// name is still in use and a First of 0 that it was used from the club's first season.
+type ClubAlias struct {
+	Name  string `json:"name"`
+	First int    `json:"first,omitempty"`
+	Last  int    `json:"last,omitempty"`
+}
+
+// Club is an MLS club, past or present
+type Club struct {
+	ID         string      `json:"id"`   // abbreviation used for the club in parsed data
+	Name       string      `json:"name"` // full name the data files use for the club
+	Conference string      `json:"conference,omitempty"`
+	Seasons    ClubSeason  `json:"seasons"`
+	Aliases    []ClubAlias `json:"aliases,omitempty"`
+	Codes      []string    `json:"codes,omitempty"` // abbreviations used by other data sources
+}
+
+// Registry is every MLS club, past and present, with the MLS player pool. Conferences are
+// those of the club's latest season.
+var Registry = []Club{
+	{ID: "ATL", Name: "Atlanta United", Conference: East, Seasons: ClubSeason{First: 2017},
+		Aliases: []ClubAlias{{Name: "Atlanta United FC"}}},
+	{ID: "AFC", Name: "Austin FC", Conference: West, Seasons: ClubSeason{First: 2021}, Codes: []string{"ATX"}},
+	{ID: "CLT", Name: "Charlotte FC", Conference: East, Seasons: ClubSeason{First: 2022}},
+	{ID: "CHI", Name: "Chicago Fire", Conference: East, Seasons: ClubSeason{First: 1998},
+		Aliases: []ClubAlias{{Name: "Chicago Fire FC", First: 2020}}},
+	{ID: "CHV", Name: "Chivas USA", Conference: West, Seasons: ClubSeason{First: 2005, Last: 2014}},
+	{ID: "CIN", Name: "FC Cincinnati", Conference: East, Seasons: ClubSeason{First: 2019}},
+	{ID: "COL", Name: "Colorado Rapids", Conference: West, Seasons: ClubSeason{First: 1996}},
+	{ID: "CLB", Name: "Columbus Crew", Conference: East, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "Columbus Crew SC", First: 2015, Last: 2020}}},
+	{ID: "DAL", Name: "FC Dallas", Conference: West, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "Dallas Burn", Last: 2004}}, Codes: []string{"FCD"}},
+	{ID: "DC", Name: "DC United", Conference: East, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "D.C. United"}}, Codes: []string{"DCU"}},
+	{ID: "HOU", Name: "Houston Dynamo", Conference: West, Seasons: ClubSeason{First: 2006},
+		Aliases: []ClubAlias{{Name: "Houston Dynamo FC", First: 2021}}},
+	{ID: "KC", Name: "Sporting Kansas City", Conference: West, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "Kansas City Wizards", Last: 2010}}, Codes: []string{"SKC"}},
+	{ID: "LA", Name: "LA Galaxy", Conference: West, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "Los Angeles Galaxy"}}, Codes: []string{"LAG"}},
+	{ID: "LAFC", Name: "LAFC", Conference: West, Seasons: ClubSeason{First: 2018},
+		Aliases: []ClubAlias{{Name: "Los Angeles FC"}}},
+	{ID: "MIA", Name: "Inter Miami", Conference: East, Seasons: ClubSeason{First: 2020},
+		Aliases: []ClubAlias{{Name: "Inter Miami CF"}}},
+	{ID: "MNUFC", Name: "Minnesota United", Conference: West, Seasons: ClubSeason{First: 2017},
+		Aliases: []ClubAlias{{Name: "Minnesota United FC"}}, Codes: []string{"MIN"}},
+	{ID: "MTL", Name: "CF Montreal", Conference: East, Seasons: ClubSeason{First: 2012},
+		Aliases: []ClubAlias{{Name: "Montreal Impact", Last: 2020}, {Name: "Montreal"}}},
+	{ID: "NSC", Name: "Nashville SC", Conference: East, Seasons: ClubSeason{First: 2020}, Codes: []string{"NSH"}},
+	{ID: "NE", Name: "New England Revolution", Conference: East, Seasons: ClubSeason{First: 1996},
+		Codes: []string{"NER"}},
+	{ID: "NYCFC", Name: "New York City FC", Conference: East, Seasons: ClubSeason{First: 2015},
+		Codes: []string{"NYC"}},
+	{ID: "NYRB", Name: "New York Red Bulls", Conference: East, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "MetroStars", Last: 2005}, {Name: "NY"}}},
+	{ID: "ORL", Name: "Orlando City SC", Conference: East, Seasons: ClubSeason{First: 2015}},
+	{ID: "PHI", Name: "Philadelphia Union", Conference: East, Seasons: ClubSeason{First: 2010}},
+	{ID: "POR", Name: "Portland Timbers", Conference: West, Seasons: ClubSeason{First: 2011}},
+	{ID: "RSL", Name: "Real Salt Lake", Conference: West, Seasons: ClubSeason{First: 2005}},
+	{ID: "SDFC", Name: "San Diego FC", Conference: West, Seasons: ClubSeason{First: 2025}, Codes: []string{"SD"}},
+	{ID: "SJ", Name: "San Jose Earthquakes", Conference: West, Seasons: ClubSeason{First: 1996},
+		Aliases: []ClubAlias{{Name: "San Jose Clash", Last: 1999}}, Codes: []string{"SJE"}},
+	{ID: "SEA", Name: "Seattle Sounders FC", Conference: West, Seasons: ClubSeason{First: 2009}},
+	{ID: "STL", Name: "St. Louis City SC", Conference: West, Seasons: ClubSeason{First: 2023},
+		Aliases: []ClubAlias{{Name: "St. Louis SC"}}},
+	{ID: "TOR", Name: "Toronto FC", Conference: East, Seasons: ClubSeason{First: 2007}},
+	{ID: "VAN", Name: "Vancouver Whitecaps", Conference: West, Seasons: ClubSeason{First: 2011},
+		Aliases: []ClubAlias{{Name: "Vancouver Whitecaps FC"}}},
+	{ID: "MLS", Name: "MLS Pool", Aliases: []ClubAlias{{Name: "Major League Soccer"}}},
+}
+
+// AllClubs maps the full names and aliases of every club in Registry to its ID
+var AllClubs = func() Clubs {
+	c := make(Clubs)
+	for _, club := range Registry {
+		c[club.Name] = club.ID
+		for _, alias := range club.Aliases {
+			c[alias.Name] = club.ID
+		}
+	}
+	return c
+}()
+
+// clubLookup maps the upper case IDs, names, aliases, and codes in Registry to their clubs
+var clubLookup = func() map[string]Club {
+	m := make(map[string]Club)
+	for _, club := range Registry {
+		m[strings.ToUpper(club.ID)] = club
+		m[strings.ToUpper(club.Name)] = club
+		for _, alias := range club.Aliases {
+			m[strings.ToUpper(alias.Name)] = club
+		}
+		for _, code := range club.Codes {
+			m[strings.ToUpper(code)] = club
+		}
+	}
+	return m
+}()
+
+// LookupClub returns the club with the ID, name, alias, or code s, ignoring case
+func LookupClub(s string) (Club, bool) {
+	club, ok := clubLookup[strings.ToUpper(strings.TrimSpace(s))]
+	return club, ok
+}
+
+// ClubIDs returns the IDs of every club in Registry, sorted
+func ClubIDs() []string {
+	ids := make([]string, len(Registry))
+	for i, club := range Registry {
+		ids[i] = club.ID
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// NameIn returns the name the club went by in season, or its Name if no alias covers season
+func (c Club) NameIn(season int) string {
+	for _, alias := range c.Aliases {
+		if (alias.First != 0 || alias.Last != 0) && (alias.First == 0 || alias.First <= season) &&
+			(alias.Last == 0 || season <= alias.Last) {
+			return alias.Name
+		}
+	}
+	return c.Name
+}
+
+// ClubSeason is the first and, for clubs that folded, last MLS season of a club
+type ClubSeason struct {
+	First int `json:"first"`
+	Last  int `json:"last,omitempty"`
+}
+
+// ClubSeasons maps club IDs to their seasons in the league. Data files list players signed by
+// expansion clubs before their first season.
+var ClubSeasons = func() map[string]ClubSeason {
+	m := make(map[string]ClubSeason)
+	for _, club := range Registry {
+		if club.Seasons.First != 0 {
+			m[club.ID] = club.Seasons
+		}
+	}
+	return m
+}()
+
+// InLeague returns true if the club abbreviation played in MLS in season. Clubs without
+// ClubSeasons and a season of 0 are always in the league.
+func InLeague(club string, season int) bool {
+	s, ok := ClubSeasons[club]
+	if !ok || season == 0 {
+		return true
+	}
+	return s.First <= season && (s.Last == 0 || season <= s.Last)
+}
+
+// InRelease returns true if players of the club abbreviation can be listed in a data file of
+// season: the seasons the club is InLeague, and the season before its first, when expansion
+// clubs sign players. Other clubs in a data file are usually misclassified aliases.
+func InRelease(club string, season int) bool {
+	if s, ok := ClubSeasons[club]; ok && season == s.First-1 {
+		return true
+	}
+	return InLeague(club, season)
+}
+
+// clubAbvs maps the full and abbreviated names in AllClubs to the abbreviations in AllClubs,
+// so parsed players share one copy of each club name
+var clubAbvs = func() map[string]string {
+	m := make(map[string]string, 2*len(AllClubs))
+	for name, abv := range AllClubs {
+		m[name] = abv
+		m[abv] = abv
+	}
+	return m
+}()
+
+// Set sets the value of clubs
+func (c *Clubs) Set(s string) error {
+	*c = make(Clubs)
+	for _, name := range strings.Split(s, ",") {
+		club, ok := LookupClub(name)
+		if !ok {
+			return fmt.Errorf("valid clubs: %s", strings.Join(ClubIDs(), ", "))
+		}
+		(*c)[club.Name] = club.ID
+	}
+	return nil
+}
+
+func (c *Clubs) getKey(val string) (string, bool) {
+	for key, value := range *c {
+		if val == value {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// HasVal returns true if s is the full or abbreviated name of a club
+func (c *Clubs) HasVal(val string) bool {
+	if _, ok := (*c)[val]; ok {
+		return true
+	}
+	_, ok := (*c).getKey(val)
+	return ok
+}
+
+// Abv returns the abbreviated name of a club
+func (c *Clubs) Abv(fullName string) (abvName string) {
+	if abv, ok := (*c)[fullName]; ok {
+		return abv
+	}
+	if _, ok := (*c).getKey(fullName); ok {
+		return fullName
+	}
+	return ""
+}
+
+// String returns club names as a comma separated list of abbreviated names
+func (c *Clubs) String() string {
+	var names []string
+	for _, val := range *c {
+		names = append(names, val)
+	}
+	return strings.Join(names, ", ")
+}
+
+// ClubTotals maps club names to total compensation
+type ClubTotals map[string]Money
+
+// KeyValue holds a key/value pair
+type KeyValue struct {
+	Key   string
+	Value Money
+}
+
+// Sort returns a sorted slice of ClubTotals key/value pairs
+func (ct *ClubTotals) Sort() []KeyValue {
+	p := make([]KeyValue, len(*ct))
+	i := 0
+	for k, v := range *ct {
+		p[i] = KeyValue{k, v}
+		i++
+	}
+	// ties are broken by club so the order is the same on every run
+	sort.SliceStable(p, func(i, j int) bool {
+		if p[i].Value != p[j].Value {
+			return p[i].Value > p[j].Value
+		}
+		return p[i].Key < p[j].Key
+	})
+	return p
+}
+
+// ClubSummary is the payroll of one club
+type ClubSummary struct {
+	Club         string
+	Compensation Money // total guaranteed compensation
+	BaseSalary   Money // total base salary
+	Players      int
+	Average      Money  // average guaranteed compensation
+	Median       Money  // median guaranteed compensation
+	Top          Player // highest paid player
+}
+
+// Summarize returns the payroll summary of each club in players, keyed by club
+func Summarize(players Players) map[string]ClubSummary {
+	comps := make(map[string][]Money)
+	summaries := make(map[string]ClubSummary)
+	for _, p := range players {
+		s := summaries[p.Club]
+		s.Club = p.Club
+		s.Compensation += p.Compensation
+		s.BaseSalary += p.BaseSalary
+		s.Players++
+		if s.Players == 1 || p.Compensation > s.Top.Compensation {
+			s.Top = p
+		}
+		summaries[p.Club] = s
+		comps[p.Club] = append(comps[p.Club], p.Compensation)
+	}
+	for club, s := range summaries {
+		vals := comps[club]
+		sort.Slice(vals, func(i, j int) bool { return vals[i] < vals[j] })
+		s.Average = s.Compensation.Div(s.Players)
+		if half := len(vals) / 2; len(vals)%2 == 0 {
+			s.Median = (vals[half-1] + vals[half]).Div(2)
+		} else {
+			s.Median = vals[half]
+		}
+		summaries[club] = s
+	}
+	return summaries
+}
@@ -0,0 +1,122 @@
+package mlsdata
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+)
+
+// csvColumns maps the fields of a player to the csv column titles used for them in MLSPA releases
+var csvColumns = map[string][]string{
+	"first": {"first name"},
+	"last":  {"last name"},
+	"name":  {"name", "player"},
+	"club":  {"club", "team"},
+	"pos":   {"position", "position(s)", "pos"},
+	"base":  {"base salary"},
+	"comp":  {"guaranteed compensation", "total compensation", "guaranteed comp"},
+}
+
+// NewCSVParser returns a Parser reading a csv MLSPA release from r. Records before the
+// header row naming the columns are skipped. As with NewParser, a leading byte order mark is
+// skipped and fields that aren't UTF-8 are read as Windows-1252.
+func NewCSVParser(r io.Reader) *Parser {
+	cr := csv.NewReader(skipBOM(r))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	return &Parser{csv: cr, Skip: CSVSkipRules}
+}
+
+// csvHeader returns the column index of each player field named in record.
+// It returns false if record doesn't name the club, compensation, and player name columns.
+func csvHeader(record []string) (map[string]int, bool) {
+	cols := make(map[string]int)
+	for i, title := range record {
+		title = strings.ToLower(strings.TrimSpace(title))
+		for field, titles := range csvColumns {
+			for _, t := range titles {
+				if title == t {
+					cols[field] = i
+				}
+			}
+		}
+	}
+	_, club := cols["club"]
+	_, comp := cols["comp"]
+	_, name := cols["name"]
+	_, last := cols["last"]
+	return cols, club && comp && (name || last)
+}
+
+// nextCSV returns the next player of a csv release
+func (p *Parser) nextCSV() (Player, error) {
+	for {
+		record, err := p.csv.Read()
+		if err == io.EOF && p.cols == nil {
+			return Player{}, errors.New("csv header row not found")
+		}
+		if err != nil {
+			return Player{}, err
+		}
+		p.line, _ = p.csv.FieldPos(0)
+		for i := range record {
+			record[i] = toUTF8(record[i])
+		}
+		if p.cols == nil {
+			if cols, ok := csvHeader(record); ok {
+				p.cols = cols
+			} else {
+				p.skip(strings.Join(record, ","), ReasonBeforeHeader)
+			}
+			continue
+		}
+		player, ok := p.csvPlayer(record)
+		if !ok {
+			p.skip(strings.Join(record, ","), ReasonBadRecord)
+			continue
+		}
+		// overrides are applied first, so corrected players aren't skipped or reported
+		player.File, player.Line = p.File, p.line
+		player.Overridden = p.Overrides.apply(&player)
+		if reason := p.Skip.reason(player); reason != "" {
+			p.skip(strings.Join(record, ","), reason)
+			continue
+		}
+		p.checkPlayer(player, strings.Join(record, ","))
+		return player, nil
+	}
+}
+
+// csvPlayer returns the player in record, or false if record isn't a player
+func (p *Parser) csvPlayer(record []string) (Player, bool) {
+	field := func(name string) string {
+		if i, ok := p.cols[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var player Player
+	player.Name = field("name")
+	if player.Name == "" {
+		player.Name = field("first") + " " + field("last")
+	}
+	player.Name = cleanName(player.Name)
+	comp, err := ParseMoney(field("comp"))
+	if player.Name == "" || err != nil {
+		return Player{}, false
+	}
+	player.Compensation = comp
+	player.BaseSalary, _ = ParseMoney(field("base"))
+
+	player.Club = field("club")
+	if abv, ok := clubAbvs[player.Club]; ok {
+		player.Club = abv
+	}
+	for _, part := range strings.FieldsFunc(field("pos"), func(r rune) bool { return r == '/' || r == ',' }) {
+		if positions, ok := ParsePos(strings.TrimSpace(part)); ok {
+			player.Pos = append(player.Pos, positions...)
+		}
+	}
+	return player, true
+}
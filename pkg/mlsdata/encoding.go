@@ -0,0 +1,34 @@
+package mlsdata
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// utf8BOM is the byte order mark some spreadsheet exports start with
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// skipBOM returns a buffered reader of r with a leading UTF-8 byte order mark removed
+func skipBOM(r io.Reader) *bufio.Reader {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(b, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
+
+// toUTF8 returns s, decoded from Windows-1252 if it isn't valid UTF-8
+func toUTF8(s string) string {
+	if utf8.ValidString(s) {
+		return s
+	}
+	decoded, err := charmap.Windows1252.NewDecoder().String(s)
+	if err != nil {
+		return s
+	}
+	return decoded
+}
@@ -0,0 +1,53 @@
+package mlsdata
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Commaf returns v as a string with commas added
+func Commaf(v float64) string {
+	buf := &bytes.Buffer{}
+	if v < 0 {
+		buf.Write([]byte{'-'})
+		v = 0 - v
+	}
+
+	comma := []byte{','}
+
+	parts := strings.Split(strconv.FormatFloat(v, 'f', 2, 64), ".")
+	pos := 0
+	if len(parts[0])%3 != 0 {
+		pos += len(parts[0]) % 3
+		buf.WriteString(parts[0][:pos])
+		buf.Write(comma)
+	}
+	for ; pos < len(parts[0]); pos += 3 {
+		buf.WriteString(parts[0][pos : pos+3])
+		buf.Write(comma)
+	}
+	buf.Truncate(buf.Len() - 1)
+
+	if len(parts) > 1 {
+		buf.Write([]byte{'.'})
+		buf.WriteString(parts[1])
+	}
+	return buf.String()
+}
+
+// Units are the units FormatUnit scales money values to
+var Units = []string{"full", "k", "m"}
+
+// FormatUnit returns v in unit: "full" as Money.String does, "k" in thousands like "1,612.5k",
+// or "m" in millions like "1.61M"
+func FormatUnit(v Money, unit string) string {
+	switch unit {
+	case "k":
+		return strings.TrimSuffix(strings.TrimRight(Commaf(v.Float()/1e3), "0"), ".") + "k"
+	case "m":
+		return strconv.FormatFloat(v.Float()/1e6, 'f', 2, 64) + "M"
+	default:
+		return v.String()
+	}
+}
@@ -0,0 +1,74 @@
+package mlsdata
+
+import "strings"
+
+// levenshtein returns the number of single rune insertions, deletions, or substitutions
+// needed to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min3(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// maxEdits is the edit distance allowed for a search token: none for short tokens, one for
+// tokens up to nine letters, and two for longer ones
+func maxEdits(token string) int {
+	switch n := len([]rune(token)); {
+	case n < 5:
+		return 0
+	case n < 10:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// MatchName returns true if name matches the search query. Both are folded, so "hernandez"
+// matches "Hernández". A query that isn't a substring of name matches if every query word is
+// within a small edit distance of a word of name starting with the same letter, so "hernandes"
+// matches as well but "fernandez" doesn't.
+func MatchName(name, query string) bool {
+	name, query = FoldName(name), FoldName(strings.TrimSpace(query))
+	if strings.Contains(name, query) {
+		return true
+	}
+	words := strings.Fields(name)
+	for _, q := range strings.Fields(query) {
+		found := false
+		for _, w := range words {
+			if q[0] == w[0] && levenshtein(q, w) <= maxEdits(q) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return query != ""
+}
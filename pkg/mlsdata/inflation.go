@@ -0,0 +1,50 @@
+package mlsdata
+
+// CPI is the annual average US consumer price index (CPI-U, 1982-84 = 100) of each season
+// with a data file, from the Bureau of Labor Statistics
+var CPI = map[int]float64{
+	2013: 232.957, 2014: 236.736, 2015: 237.017, 2016: 240.007, 2017: 245.120, 2018: 251.107,
+	2019: 255.657, 2020: 258.811, 2021: 270.970, 2022: 292.655, 2023: 304.702, 2024: 313.689,
+}
+
+// CPISeason returns the latest season in CPI, the season values are adjusted to
+func CPISeason() int {
+	latest := 0
+	for season := range CPI {
+		if season > latest {
+			latest = season
+		}
+	}
+	return latest
+}
+
+// cpiFor returns the CPI of season, using the closest season in CPI for seasons outside it
+func cpiFor(season int) float64 {
+	closest := 0
+	for s := range CPI {
+		if closest == 0 || abs(s-season) < abs(closest-season) {
+			closest = s
+		}
+	}
+	return CPI[closest]
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// AdjustInflation returns v, an amount paid in season from, in the dollars of season to
+func AdjustInflation(v Money, from, to int) Money {
+	if from == 0 || to == 0 || from == to {
+		return v
+	}
+	return v.Scale(cpiFor(to) / cpiFor(from))
+}
+
+// AdjustRelease returns v, paid in the season of the named data file, in the dollars of CPISeason
+func AdjustRelease(v Money, data string) Money {
+	return AdjustInflation(v, DataSeason(data), CPISeason())
+}
@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package mlsdata
+
+import (
+	"io"
+	"iter"
+)
+
+// ParseIter returns an iterator over the players in a data file. Iteration stops after the first error.
+func ParseIter(r io.Reader) iter.Seq2[Player, error] {
+	return func(yield func(Player, error) bool) {
+		p := NewParser(r)
+		for {
+			player, err := p.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(player, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package mlsdata
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount of dollars held in whole cents, so totals and comparisons of salaries have
+// no float rounding artifacts. Money values are added, subtracted, and compared as integers.
+type Money int64
+
+// Dollar is one dollar, for writing Money constants like 30_000 * Dollar
+const Dollar Money = 100
+
+// Dollars returns d dollars rounded to the nearest cent
+func Dollars(d float64) Money {
+	return Money(math.Round(d * 100))
+}
+
+// ParseMoney parses an amount like "$1,612,500.00" or "-250000"
+func ParseMoney(s string) (Money, error) {
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(s)
+	d, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err
+	}
+	return Dollars(d), nil
+}
+
+// Float returns m in dollars, for ratios, averages, and other statistics
+func (m Money) Float() float64 {
+	return float64(m) / 100
+}
+
+// Scale returns m multiplied by f, rounded to the nearest cent
+func (m Money) Scale(f float64) Money {
+	return Dollars(m.Float() * f)
+}
+
+// Div returns m divided by n, rounded to the nearest cent, or 0 if n is 0
+func (m Money) Div(n int) Money {
+	if n == 0 {
+		return 0
+	}
+	return m.Scale(1 / float64(n))
+}
+
+// Abs returns the absolute value of m
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// String returns m with commas and cents, like "1,612,500.00"
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign, m = "-", -m
+	}
+	dollars := strconv.FormatInt(int64(m/100), 10)
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range dollars {
+		if i > 0 && (len(dollars)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	cents := strconv.FormatInt(int64(m%100), 10)
+	if len(cents) == 1 {
+		cents = "0" + cents
+	}
+	b.WriteString("." + cents)
+	return b.String()
+}
+
+// Set parses an amount like ParseMoney, for flags of Money values
+func (m *Money) Set(s string) error {
+	v, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
+
+// MarshalJSON encodes m as a number of dollars
+func (m Money) MarshalJSON() ([]byte, error) {
+	return strconv.AppendFloat(nil, m.Float(), 'f', -1, 64), nil
+}
+
+// UnmarshalJSON decodes a number of dollars
+func (m *Money) UnmarshalJSON(b []byte) error {
+	v, err := ParseMoney(strings.Trim(string(b), `"`))
+	if err != nil {
+		return err
+	}
+	*m = v
+	return nil
+}
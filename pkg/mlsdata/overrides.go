@@ -0,0 +1,79 @@
+package mlsdata
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Override corrects the club or positions of a player in one data file
+type Override struct {
+	Club string
+	Pos  Pos
+}
+
+// Overrides maps data file names and player name keys to corrections
+type Overrides map[string]Override
+
+func overrideKey(file, name string) string { return file + "\t" + NameKey(name) }
+
+// ReadOverrides reads csv records of data file, player name, club, and positions. Clubs are
+// looked up by ID, name, alias, or code.
+// An empty club or positions field leaves the parsed value unchanged.
+// Multiple positions are separated by "/". A header row is skipped.
+func ReadOverrides(r io.Reader) (Overrides, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 4
+	cr.TrimLeadingSpace = true
+	overrides := make(Overrides)
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return overrides, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		file, name := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if line == 1 && strings.EqualFold(file, "data") {
+			continue
+		}
+		var o Override
+		if club := strings.TrimSpace(record[2]); club != "" {
+			c, ok := LookupClub(club)
+			if !ok {
+				return nil, fmt.Errorf("line %d: unknown club %q", line, record[2])
+			}
+			o.Club = c.ID
+		}
+		if pos := strings.TrimSpace(record[3]); pos != "" {
+			for _, part := range strings.Split(pos, "/") {
+				parsed, ok := ParsePos(strings.TrimSpace(part))
+				if !ok {
+					return nil, fmt.Errorf("line %d: unknown position %q", line, part)
+				}
+				o.Pos = append(o.Pos, parsed...)
+			}
+		}
+		overrides[overrideKey(file, name)] = o
+	}
+}
+
+// apply corrects player if there is an override for it, reporting whether one was applied
+func (o Overrides) apply(player *Player) bool {
+	if len(o) == 0 {
+		return false
+	}
+	override, ok := o[overrideKey(player.File, player.Name)]
+	if !ok {
+		return false
+	}
+	if override.Club != "" {
+		player.Club = override.Club
+	}
+	if override.Pos != nil {
+		player.Pos = append(Pos(nil), override.Pos...)
+	}
+	return true
+}
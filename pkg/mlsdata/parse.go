@@ -0,0 +1,171 @@
+// Package mlsdata reads MLS Players Association salary data files.
+//
+// Parse reads every player in a data file:
+//
+//	f, err := os.Open("2024_09_13_data")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	players, err := mlsdata.Parse(f)
+//
+// A Parser reads one player at a time, and ParseIter ranges over them with Go 1.23 iterators.
+package mlsdata
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"path"
+	"strings"
+)
+
+// Parser reads players from a data file one line at a time
+type Parser struct {
+	// File is the name of the data file, recorded in each player's File field
+	File string
+	// Overrides correct the club or positions of players in the data file named File
+	Overrides Overrides
+	// Skipped, if set, is called with the line number and text of lines that don't look like a player
+	Skipped func(line int, text string)
+	// Skip decides which lines are not players. NewParser and NewCSVParser set it to
+	// TextSkipRules and CSVSkipRules.
+	Skip SkipRules
+	// Report, if set, collects the lines that were skipped or are missing a player field
+	Report *ParseReport
+
+	r       *bufio.Reader
+	scanner *bufio.Scanner
+	sep     string
+	line    int
+	err     error // returned by Next, for files that couldn't be opened
+
+	// csv releases
+	csv  *csv.Reader
+	cols map[string]int
+}
+
+// NewParser returns a Parser reading from r. A leading byte order mark is skipped, and lines
+// that aren't UTF-8 are read as Windows-1252.
+func NewParser(r io.Reader) *Parser {
+	return &Parser{r: skipBOM(r), Skip: TextSkipRules}
+}
+
+// Next returns the next player, skipping lines that don't look like a player.
+// It returns io.EOF when there are no more players.
+func (p *Parser) Next() (Player, error) {
+	if p.err != nil {
+		return Player{}, p.err
+	}
+	if p.csv != nil {
+		return p.nextCSV()
+	}
+	if p.scanner == nil {
+		// data files starting with a tab are tab separated
+		p.sep = " "
+		if b, _ := p.r.ReadByte(); string(b) == "\t" {
+			p.sep = "\t"
+		} else {
+			_ = p.r.UnreadByte()
+		}
+		p.scanner = bufio.NewScanner(p.r)
+	}
+	for p.scanner.Scan() {
+		p.line++
+		text := toUTF8(p.scanner.Text())
+		player := parseLine(text, p.sep)
+		// overrides are applied first, so corrected players aren't skipped or reported
+		player.File, player.Line = p.File, p.line
+		player.Overridden = p.Overrides.apply(&player)
+		if reason := p.Skip.reason(player); reason != "" {
+			p.skip(text, reason)
+			continue
+		}
+		p.checkPlayer(player, text)
+		return player, nil
+	}
+	if err := p.scanner.Err(); err != nil {
+		return Player{}, err
+	}
+	return Player{}, io.EOF
+}
+
+// Parse reads all the players from a data file
+func Parse(r io.Reader) (Players, error) {
+	return NewParser(r).All()
+}
+
+// NewFileParser returns a Parser reading the data file name from r, using a csv Parser
+// for ".csv" files and an Excel Parser for ".xlsx" files
+func NewFileParser(name string, r io.Reader) *Parser {
+	var p *Parser
+	switch {
+	case strings.EqualFold(path.Ext(name), ".xlsx"):
+		p = NewXLSXParser(r)
+	case strings.EqualFold(path.Ext(name), ".csv"):
+		p = NewCSVParser(r)
+	default:
+		p = NewParser(r)
+	}
+	p.File = name
+	return p
+}
+
+// All reads the remaining players
+func (p *Parser) All() (Players, error) {
+	var all Players
+	for {
+		player, err := p.Next()
+		if err == io.EOF {
+			return all, nil
+		}
+		if err != nil {
+			return all, err
+		}
+		all = append(all, player)
+	}
+}
+
+// parseLine classifies the sep separated tokens of a line into the fields of a player
+func parseLine(line, sep string) Player {
+	player := Player{}
+	for _, token := range strings.Split(line, sep) {
+		if token == "" {
+			continue
+		}
+		club, isClub := clubAbvs[token]
+		positions, isPos := ParsePos(token)
+		switch {
+		case isClub:
+			player.Club = club
+
+		case isPos:
+			player.Pos = append(player.Pos, positions...)
+
+		case token[0] == '$', token[0] >= '0' && token[0] <= '9':
+			// a trailing comma ends a date in a title line, like "September 15, 2013", not an amount
+			if token = strings.TrimLeft(token, "$"); token == "" || strings.HasSuffix(token, ",") {
+				continue
+			}
+
+			val, err := ParseMoney(token)
+			if err != nil {
+				continue
+			}
+
+			if player.BaseSalary == 0 {
+				player.BaseSalary = val
+			} else {
+				player.Compensation = val
+			}
+
+		default:
+			if player.Name == "" {
+				player.Name = token
+			} else {
+				player.Name += " " + token
+			}
+		}
+	}
+	player.Name = cleanName(player.Name)
+	return player
+}
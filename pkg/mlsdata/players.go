@@ -0,0 +1,245 @@
+package mlsdata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Player is an MLS player
+type Player struct {
+	Club         string `json:"club"`
+	Name         string `json:"name"`
+	Pos          Pos    `json:"pos"`
+	BaseSalary   Money  `json:"base_salary"`
+	Compensation Money  `json:"compensation"`
+	Mechanism    string `json:"mechanism,omitempty"`
+	OnLoan       bool   `json:"on_loan,omitempty"`
+	// ClubInferred is set if the release listed no club and Club is from an earlier release
+	ClubInferred bool `json:"club_inferred,omitempty"`
+	// Charge is the budget charge classification of the player under their season's Rules
+	Charge string `json:"budget_charge,omitempty"`
+
+	// File and Line are the data file and line number the player was read from.
+	// Overridden is set if the club or positions were corrected by an Override.
+	File       string `json:"file,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Overridden bool   `json:"overridden,omitempty"`
+}
+
+// Players is a list of MLS Players
+type Players []Player
+
+// BackfillClubs sets the club of players without one to their club in prev, matching players by
+// name key, and marks the club as inferred. It returns the number of players given a club.
+func (p Players) BackfillClubs(prev Players) int {
+	clubs := make(map[string]string, len(prev))
+	for _, player := range prev {
+		if player.Club != "" {
+			clubs[NameKey(player.Name)] = player.Club
+		}
+	}
+	n := 0
+	for i := range p {
+		if p[i].Club != "" {
+			continue
+		}
+		if club, ok := clubs[NameKey(p[i].Name)]; ok {
+			p[i].Club, p[i].ClubInferred = club, true
+			n++
+		}
+	}
+	return n
+}
+
+// Set sets the value of Players from a comma separated list
+func (p *Players) Set(s string) error {
+	names := strings.Split(s, ",")
+	for _, name := range names {
+		*p = append(*p, Player{Name: strings.TrimSpace(name)})
+	}
+	return nil
+}
+
+func (p *Players) String() string {
+	names := make([]string, len(*p), len(*p))
+	for _, player := range *p {
+		names = append(names, player.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// HasVal returns true if val matches any of the player names, see MatchName
+func (p *Players) HasVal(val string) bool {
+	for _, player := range *p {
+		if MatchName(val, player.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveDiacritics returns s with nonspacing marks removed, e.g. "Almirón" becomes "Almiron"
+func RemoveDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	removed, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+	return removed
+}
+
+// FoldName returns name in lower case with diacritics removed
+func FoldName(name string) string {
+	return strings.ToLower(RemoveDiacritics(name))
+}
+
+// nameSuffixes are generational suffixes that some data files leave off a player's name
+var nameSuffixes = map[string]bool{"jr": true, "sr": true, "ii": true, "iii": true, "iv": true}
+
+// isNameSuffix returns true if token is a generational suffix like "Jr." or "III"
+func isNameSuffix(token string) bool {
+	return nameSuffixes[strings.ToLower(strings.Trim(token, ",."))]
+}
+
+// nameStatuses are roster statuses that recent data files list in the club column of players
+// without a club, and so are read as part of the name
+var nameStatuses = map[string]bool{"retired": true, "substitute": true}
+
+// cleanName removes the comma some data files put before a suffix, so
+// "Marcucci, Jr." becomes "Marcucci Jr."
+func cleanName(name string) string {
+	tokens := strings.Fields(name)
+	for i := 1; i < len(tokens); i++ {
+		if isNameSuffix(tokens[i]) {
+			tokens[i-1] = strings.TrimRight(tokens[i-1], ",")
+		}
+	}
+	return strings.Join(tokens, " ")
+}
+
+// NameKey returns a normalized player name used to match players across data files.
+// Older data files list the last name first, so the name tokens are sorted. Suffixes
+// and roster statuses are dropped, so "Marcucci, Jr." matches "Marcucci" and "Steve Birnbaum
+// Retired" matches "Steve Birnbaum", unless they are the whole name.
+func NameKey(name string) string {
+	var tokens []string
+	for _, token := range strings.Fields(FoldName(name)) {
+		if token = strings.Trim(token, ","); token != "" && !isNameSuffix(token) && !nameStatuses[token] {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		tokens = strings.Fields(FoldName(name))
+	}
+	sort.Strings(tokens)
+	return strings.Join(tokens, " ")
+}
+
+// Pos is the set of player positions
+type Pos []string
+
+// AllPos is every known player position
+var AllPos = Pos{"F", "GK", "D", "M",
+	"Center-back", "Defensive Midfield", "Right Wing", "Central Midfield", "Center Forward", "Right-back",
+	"Attacking Midfield", "Goalkeeper", "Left-back", "Left Wing", "Right Midfield", "Left Midfield",
+	"Midfielder", "Forward", "Defender"}
+
+// posNames maps upper case positions to the strings in AllPos, so parsed players
+// share one copy of each position
+var posNames = func() map[string]string {
+	m := make(map[string]string, len(AllPos))
+	for _, pos := range AllPos {
+		m[strings.ToUpper(pos)] = pos
+	}
+	return m
+}()
+
+// posGroups maps positions to the GK, D, M, or F position group
+var posGroups = map[string]string{
+	"GK": "GK", "GOALKEEPER": "GK",
+	"D": "D", "CENTER-BACK": "D", "RIGHT-BACK": "D", "LEFT-BACK": "D", "DEFENDER": "D",
+	"M": "M", "DEFENSIVE MIDFIELD": "M", "CENTRAL MIDFIELD": "M",
+	"ATTACKING MIDFIELD": "M", "RIGHT MIDFIELD": "M", "LEFT MIDFIELD": "M", "MIDFIELDER": "M",
+	"F": "F", "RIGHT WING": "F", "LEFT WING": "F", "CENTER FORWARD": "F", "FORWARD": "F",
+}
+
+// PosGroup returns the position group of pos, or "" if pos is unknown
+func PosGroup(pos string) string {
+	return posGroups[strings.ToUpper(pos)]
+}
+
+// ParsePos returns the positions in token. Hybrid positions like "M-F", "D/M" or "MF"
+// are split into their single letter positions.
+func ParsePos(token string) (Pos, bool) {
+	upper := strings.ToUpper(token)
+	if pos, ok := posNames[upper]; ok {
+		return Pos{pos}, true
+	}
+	parts := strings.FieldsFunc(upper, func(r rune) bool { return r == '-' || r == '/' })
+	if len(parts) == 1 {
+		parts = strings.Split(parts[0], "")
+	}
+	if len(parts) < 2 {
+		return nil, false
+	}
+	positions := make(Pos, len(parts))
+	for i, part := range parts {
+		pos, ok := posNames[part]
+		if !ok || len(pos) != 1 {
+			return nil, false
+		}
+		positions[i] = pos
+	}
+	return positions, true
+}
+
+// HasVal returns true if s is in p
+func (p *Pos) HasVal(s string) bool {
+	for _, pos := range *p {
+		if strings.EqualFold(pos, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAny returns true if any of the positions in q is in p
+func (p *Pos) HasAny(q Pos) bool {
+	for _, s := range q {
+		if p.HasVal(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Group returns the position group of the first position in p
+func (p *Pos) Group() string {
+	if len(*p) == 0 {
+		return ""
+	}
+	return PosGroup((*p)[0])
+}
+
+// Set sets the value of p from a comma separated list of positions
+func (p *Pos) Set(s string) error {
+	for _, pos := range strings.Split(s, ",") {
+		parsed, ok := ParsePos(strings.ToUpper(strings.TrimSpace(pos)))
+		if !ok {
+			return fmt.Errorf("valid values: %s", AllPos.String())
+		}
+		*p = append(*p, parsed...)
+	}
+	return nil
+}
+
+func (p *Pos) String() string { return strings.Join(*p, ", ") }
+
+// Label returns the positions joined with "/"
+func (p *Pos) Label() string { return strings.Join(*p, "/") }
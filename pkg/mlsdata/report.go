@@ -0,0 +1,70 @@
+package mlsdata
+
+import "strings"
+
+// Parse issue reasons
+const (
+	ReasonNotPlayer    = "not a player"            // line skipped by the parser's SkipRules
+	ReasonNoComp       = "no compensation"         // line skipped with no compensation amount
+	ReasonBeforeHeader = "before csv header"       // csv record skipped before the header row
+	ReasonBadRecord    = "no name or compensation" // csv record skipped
+	ReasonNoClub       = "no club"
+	ReasonUnknownClub  = "unknown club"
+	ReasonClubInactive = "club not in the league that season"
+	ReasonNoPos        = "no position"
+)
+
+// ParseIssue is a line of a data file that was skipped or only partly parsed
+type ParseIssue struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Text   string `json:"text"`
+	Reason string `json:"reason"`
+	// Skipped is set if the line was dropped, rather than read as a player missing a field
+	Skipped bool `json:"skipped"`
+}
+
+// ParseReport collects the issues found parsing data files
+type ParseReport struct {
+	Issues []ParseIssue
+}
+
+// Counts returns the number of issues with each reason
+func (r *ParseReport) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range r.Issues {
+		counts[issue.Reason]++
+	}
+	return counts
+}
+
+// skip records a line dropped by the parser and calls Skipped. Blank lines aren't reported.
+func (p *Parser) skip(text, reason string) {
+	if p.Skipped != nil {
+		p.Skipped(p.line, text)
+	}
+	if p.Report != nil && strings.TrimSpace(text) != "" {
+		p.Report.Issues = append(p.Report.Issues, ParseIssue{File: p.File, Line: p.line, Text: text, Reason: reason, Skipped: true})
+	}
+}
+
+// checkPlayer records the fields missing from a player read from text
+func (p *Parser) checkPlayer(player Player, text string) {
+	if p.Report == nil {
+		return
+	}
+	add := func(reason string) {
+		p.Report.Issues = append(p.Report.Issues, ParseIssue{File: p.File, Line: p.line, Text: text, Reason: reason})
+	}
+	switch {
+	case player.Club == "":
+		add(ReasonNoClub)
+	case clubAbvs[player.Club] != player.Club:
+		add(ReasonUnknownClub)
+	case !InRelease(player.Club, DataSeason(p.File)):
+		add(ReasonClubInactive)
+	}
+	if len(player.Pos) == 0 {
+		add(ReasonNoPos)
+	}
+}
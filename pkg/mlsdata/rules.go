@@ -0,0 +1,162 @@
+package mlsdata
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// Budget charge classifications
+const (
+	ChargeBudget = "budget" // charged against the salary budget at their compensation
+	ChargeTAM    = "TAM"    // paid above the max budget charge, bought down with Targeted Allocation Money
+	ChargeDP     = "DP"     // designated player, charged at the max budget charge
+)
+
+// Rules are the MLS roster rules of a season
+type Rules struct {
+	Season          int   `json:"season"`
+	SalaryBudget    Money `json:"salary_budget"`
+	MaxBudgetCharge Money `json:"max_budget_charge"`
+	// TAMMax is the most a player bought down with Targeted Allocation Money may be paid.
+	// It is 0 before TAM was introduced in 2015.
+	TAMMax  Money `json:"tam_max,omitempty"`
+	DPSlots int   `json:"dp_slots"`
+	// Estimated is set for rules made by EstimateRules
+	Estimated bool `json:"estimated,omitempty"`
+	// Threshold, if set, overrides the DP threshold derived from the budget values
+	Threshold Money `json:"dp_threshold,omitempty"`
+}
+
+// SeasonRules are the roster rules of every season with a data file, in season order
+var SeasonRules = []Rules{
+	{Season: 2013, SalaryBudget: 2_950_000 * Dollar, MaxBudgetCharge: 368_750 * Dollar, DPSlots: 3},
+	{Season: 2014, SalaryBudget: 3_100_000 * Dollar, MaxBudgetCharge: 387_500 * Dollar, DPSlots: 3},
+	{Season: 2015, SalaryBudget: 3_490_000 * Dollar, MaxBudgetCharge: 436_250 * Dollar, TAMMax: 1_500_000 * Dollar, DPSlots: 3},
+	{Season: 2016, SalaryBudget: 3_660_000 * Dollar, MaxBudgetCharge: 457_500 * Dollar, TAMMax: 1_500_000 * Dollar, DPSlots: 3},
+	{Season: 2017, SalaryBudget: 3_845_000 * Dollar, MaxBudgetCharge: 480_625 * Dollar, TAMMax: 1_500_000 * Dollar, DPSlots: 3},
+	{Season: 2018, SalaryBudget: 4_035_000 * Dollar, MaxBudgetCharge: 504_375 * Dollar, TAMMax: 1_500_000 * Dollar, DPSlots: 3},
+	{Season: 2019, SalaryBudget: 4_240_000 * Dollar, MaxBudgetCharge: 530_000 * Dollar, TAMMax: 1_500_000 * Dollar, DPSlots: 3},
+	{Season: 2020, SalaryBudget: 4_900_000 * Dollar, MaxBudgetCharge: 612_500 * Dollar, TAMMax: 1_612_500 * Dollar, DPSlots: 3},
+	{Season: 2021, SalaryBudget: 4_900_000 * Dollar, MaxBudgetCharge: 612_500 * Dollar, TAMMax: 1_612_500 * Dollar, DPSlots: 3},
+	{Season: 2022, SalaryBudget: 4_900_000 * Dollar, MaxBudgetCharge: 612_500 * Dollar, TAMMax: 1_612_500 * Dollar, DPSlots: 3},
+	{Season: 2023, SalaryBudget: 5_210_000 * Dollar, MaxBudgetCharge: 651_250 * Dollar, TAMMax: 1_651_250 * Dollar, DPSlots: 3},
+	{Season: 2024, SalaryBudget: 5_470_000 * Dollar, MaxBudgetCharge: 683_750 * Dollar, TAMMax: 1_683_750 * Dollar, DPSlots: 3},
+}
+
+// ReadRules reads roster rules from a json object with a "rules" array, like the constants.json
+// of an -export-all archive, and returns them in season order. Every season needs a salary budget
+// and max budget charge, and may be listed once.
+func ReadRules(r io.Reader) ([]Rules, error) {
+	var v struct {
+		Rules []Rules `json:"rules"`
+	}
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return nil, err
+	}
+	if len(v.Rules) == 0 {
+		return nil, errors.New("no rules")
+	}
+	seen := make(map[int]bool, len(v.Rules))
+	for _, rules := range v.Rules {
+		switch {
+		case rules.Season <= 0:
+			return nil, fmt.Errorf("invalid season %d", rules.Season)
+		case seen[rules.Season]:
+			return nil, fmt.Errorf("season %d listed twice", rules.Season)
+		case rules.SalaryBudget <= 0 || rules.MaxBudgetCharge <= 0:
+			return nil, fmt.Errorf("season %d has no salary budget or max budget charge", rules.Season)
+		}
+		seen[rules.Season] = true
+	}
+	sort.Slice(v.Rules, func(i, j int) bool { return v.Rules[i].Season < v.Rules[j].Season })
+	return v.Rules, nil
+}
+
+// RulesFor returns the roster rules of season and true, or the rules of the closest earlier
+// season and false if season has none. Seasons before the first with rules use the first, and
+// season 0 (unknown) uses the latest.
+func RulesFor(season int) (Rules, bool) {
+	if season == 0 {
+		return SeasonRules[len(SeasonRules)-1], false
+	}
+	rules := SeasonRules[0]
+	for _, r := range SeasonRules {
+		if r.Season == season {
+			return r, true
+		}
+		if r.Season < season {
+			rules = r
+		}
+	}
+	return rules, false
+}
+
+// EstimateRules estimates the rules of a season without known rules from its players, given
+// the known rules and players of another season. The known salary budget is scaled by the change
+// in the median guaranteed compensation between the two sets of players and rounded to $10,000.
+// The max budget charge is an eighth of the salary budget, as in every known season, and the TAM
+// maximum stays $1,000,000 above it, as it has since 2020. DP slots are unchanged.
+func EstimateRules(season int, players Players, known Rules, knownPlayers Players) Rules {
+	est := known
+	est.Season, est.Estimated = season, true
+	if m, k := medianComp(players), medianComp(knownPlayers); m > 0 && k > 0 {
+		est.SalaryBudget = Dollars(math.Round(known.SalaryBudget.Float()*m.Float()/k.Float()/10_000) * 10_000)
+		est.MaxBudgetCharge = est.SalaryBudget.Div(8)
+		if known.TAMMax > 0 {
+			est.TAMMax = est.MaxBudgetCharge + 1_000_000*Dollar
+		}
+	}
+	return est
+}
+
+// medianComp returns the median guaranteed compensation of players
+func medianComp(players Players) Money {
+	var comps []Money
+	for _, p := range players {
+		comps = append(comps, p.Compensation)
+	}
+	if len(comps) == 0 {
+		return 0
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i] < comps[j] })
+	half := len(comps) / 2
+	if len(comps)%2 == 0 {
+		return (comps[half-1] + comps[half]).Div(2)
+	}
+	return comps[half]
+}
+
+// DPThreshold returns the compensation above which a player must be a designated player
+func (r Rules) DPThreshold() Money {
+	if r.Threshold > 0 {
+		return r.Threshold
+	}
+	if r.TAMMax > 0 {
+		return r.TAMMax
+	}
+	return r.MaxBudgetCharge
+}
+
+// Classify returns the budget charge classification of a player paid compensation
+func (r Rules) Classify(compensation Money) string {
+	switch {
+	case compensation > r.DPThreshold():
+		return ChargeDP
+	case compensation > r.MaxBudgetCharge && r.TAMMax > 0:
+		return ChargeTAM
+	default:
+		return ChargeBudget
+	}
+}
+
+// BudgetCharge returns the amount a player paid compensation is charged against the salary budget
+func (r Rules) BudgetCharge(compensation Money) Money {
+	if compensation > r.MaxBudgetCharge {
+		return r.MaxBudgetCharge
+	}
+	return compensation
+}
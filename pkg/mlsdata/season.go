@@ -0,0 +1,98 @@
+package mlsdata
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Release windows, the part of the season a data file was released in
+const (
+	Spring = "spring"
+	Fall   = "fall"
+)
+
+// Season is the season and release date of a data file
+type Season struct {
+	Year int
+	// Released is the release date, or zero if the file name has only a year
+	Released time.Time
+}
+
+// ParseSeason returns the season of a data file named like "2024_09_13_data" or
+// "2024_09_13_data.csv". The zero Season is returned if the name doesn't start with a year.
+func ParseSeason(name string) Season {
+	base := path.Base(name)
+	if len(base) < 4 {
+		return Season{}
+	}
+	year, err := strconv.Atoi(base[:4])
+	if err != nil {
+		return Season{}
+	}
+	s := Season{Year: year}
+	if len(base) >= 10 {
+		if t, err := time.Parse("2006_01_02", base[:10]); err == nil {
+			s.Released = t
+		}
+	}
+	return s
+}
+
+// Window returns Spring for releases before July and Fall for later ones, or "" if the
+// release date is unknown
+func (s Season) Window() string {
+	switch {
+	case s.Released.IsZero():
+		return ""
+	case s.Released.Month() < time.July:
+		return Spring
+	default:
+		return Fall
+	}
+}
+
+// Before returns true if s was released before o. Seasons without a release date are ordered
+// before the releases of their year.
+func (s Season) Before(o Season) bool {
+	if s.Year != o.Year {
+		return s.Year < o.Year
+	}
+	return s.Released.Before(o.Released)
+}
+
+// String returns the release date and window, like "2024-09-13 fall", or the year if the
+// release date is unknown
+func (s Season) String() string {
+	switch {
+	case s.Year == 0:
+		return ""
+	case s.Released.IsZero():
+		return strconv.Itoa(s.Year)
+	default:
+		return s.Released.Format("2006-01-02") + " " + s.Window()
+	}
+}
+
+// SortReleases sorts data file names in release order. Names without a season go first and
+// ties are broken by name.
+func SortReleases(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		si, sj := ParseSeason(names[i]), ParseSeason(names[j])
+		if si.Before(sj) {
+			return true
+		}
+		if sj.Before(si) {
+			return false
+		}
+		return strings.Compare(names[i], names[j]) < 0
+	})
+}
+
+// DataSeason returns the season of a data file named like "2024_09_13_data", or 0 if the
+// name doesn't start with a year
+func DataSeason(name string) int {
+	return ParseSeason(name).Year
+}
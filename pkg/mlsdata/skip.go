@@ -0,0 +1,59 @@
+package mlsdata
+
+import "strings"
+
+// MinCompensation is the least guaranteed compensation of a real player. Lines of a data file
+// that parse as players with less are page numbers, dates, or header and total rows.
+const MinCompensation = 30_000 * Dollar
+
+// SkipRules decide which parsed lines of a data file are not players
+type SkipRules struct {
+	// MinCompensation is the least compensation of a player. Lines with less are skipped.
+	MinCompensation Money
+	// MinFields is how many of club, position, and compensation a line needs to be read as a
+	// player. Lines with fewer are skipped.
+	MinFields int
+}
+
+// TextSkipRules skip lines of text releases with no club, position, or compensation, or too
+// little compensation. Text releases have title and column header lines, and lines split by
+// page breaks.
+var TextSkipRules = SkipRules{MinCompensation: MinCompensation, MinFields: 1}
+
+// CSVSkipRules skip only records with too little compensation, as csv releases only have player
+// records after the header row
+var CSVSkipRules = SkipRules{MinCompensation: MinCompensation, MinFields: 0}
+
+// Skip returns true if player, parsed from a line, is not a player
+func (r SkipRules) Skip(player Player) bool {
+	return r.reason(player) != ""
+}
+
+// LowCompReason is the parse issue reason for lines skipped for compensation under
+// MinCompensation
+func (r SkipRules) LowCompReason() string {
+	return "compensation under $" + strings.TrimSuffix(r.MinCompensation.String(), ".00")
+}
+
+// reason returns why player, parsed from a line, is not a player, or "" if it is one
+func (r SkipRules) reason(player Player) string {
+	fields := 0
+	if player.Club != "" {
+		fields++
+	}
+	if len(player.Pos) > 0 {
+		fields++
+	}
+	if player.Compensation >= r.MinCompensation {
+		fields++
+	}
+	switch {
+	case fields < r.MinFields:
+		return ReasonNotPlayer
+	case player.Compensation == 0:
+		return ReasonNoComp
+	case player.Compensation < r.MinCompensation:
+		return r.LowCompReason()
+	}
+	return ""
+}
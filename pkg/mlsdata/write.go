@@ -0,0 +1,17 @@
+package mlsdata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteData writes players in the tab separated data file format read by Parser
+func WriteData(w io.Writer, players Players) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("\t\n")
+	for _, p := range players {
+		fmt.Fprintf(bw, "%s\t\t%s\t%s\t$%s\t$%s\n", p.Name, p.Club, p.Pos.Label(), p.BaseSalary, p.Compensation)
+	}
+	return bw.Flush()
+}
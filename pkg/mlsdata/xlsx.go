@@ -0,0 +1,166 @@
+package mlsdata
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// xlsxSST is the shared string table of a workbook
+type xlsxSST struct {
+	Items []struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	} `xml:"si"`
+}
+
+// xlsxSheet is the cell data of a worksheet
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline struct {
+				T string `xml:"t"`
+			} `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// NewXLSXParser returns a Parser reading the first worksheet of an Excel workbook MLSPA
+// release from r. The worksheet is read as a csv release, with line numbers matching row numbers.
+func NewXLSXParser(r io.Reader) *Parser {
+	records, err := xlsxRecords(r)
+	if err != nil {
+		return &Parser{err: err}
+	}
+	buf := &bytes.Buffer{}
+	cw := csv.NewWriter(buf)
+	if err := cw.WriteAll(records); err != nil {
+		return &Parser{err: err}
+	}
+	return NewCSVParser(buf)
+}
+
+// xlsxRecords returns the rows of the first worksheet of the workbook in r. Missing rows are
+// returned as empty records and missing cells as empty fields.
+func xlsxRecords(r io.Reader) ([][]string, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]*zip.File)
+	var sheets []string
+	for _, f := range zr.File {
+		files[f.Name] = f
+		if path.Dir(f.Name) == "xl/worksheets" && path.Ext(f.Name) == ".xml" {
+			sheets = append(sheets, f.Name)
+		}
+	}
+	if len(sheets) == 0 {
+		return nil, errors.New("xlsx: no worksheets")
+	}
+	sheetName := "xl/worksheets/sheet1.xml"
+	if files[sheetName] == nil {
+		sort.Strings(sheets)
+		sheetName = sheets[0]
+	}
+
+	var sst xlsxSST
+	if f := files["xl/sharedStrings.xml"]; f != nil {
+		if err := decodeXML(f, &sst); err != nil {
+			return nil, err
+		}
+	}
+	shared := make([]string, len(sst.Items))
+	for i, item := range sst.Items {
+		shared[i] = item.T
+		for _, run := range item.Runs {
+			shared[i] += run.T
+		}
+	}
+
+	var sheet xlsxSheet
+	if err := decodeXML(files[sheetName], &sheet); err != nil {
+		return nil, err
+	}
+	var records [][]string
+	for _, row := range sheet.Rows {
+		n := row.R
+		if n == 0 {
+			n = len(records) + 1
+		}
+		for len(records) < n-1 {
+			records = append(records, []string{""})
+		}
+		var record []string
+		for i, c := range row.Cells {
+			col := xlsxColumn(c.Ref)
+			if col < 0 {
+				col = i
+			}
+			for len(record) < col {
+				record = append(record, "")
+			}
+			v := c.Value
+			switch c.Type {
+			case "s":
+				idx, err := strconv.Atoi(v)
+				if err != nil || idx < 0 || idx >= len(shared) {
+					return nil, errors.New("xlsx: invalid shared string in cell " + c.Ref)
+				}
+				v = shared[idx]
+			case "inlineStr":
+				v = c.Inline.T
+			}
+			if col < len(record) {
+				record[col] = v
+			} else {
+				record = append(record, v)
+			}
+		}
+		if len(record) == 0 {
+			record = []string{""}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// xlsxColumn returns the zero based column of a cell reference like "AB12", or -1 if ref has
+// no column letters
+func xlsxColumn(ref string) int {
+	col := 0
+	i := 0
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A'+1)
+	}
+	if i == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+// decodeXML decodes the XML of a file in a zip archive into v
+func decodeXML(f *zip.File, v interface{}) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
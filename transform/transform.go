@@ -5,34 +5,21 @@ import (
 	"log"
 	"os"
 	"strings"
-	"unicode"
 
-	"golang.org/x/text/runes"
-	"golang.org/x/text/transform"
-	"golang.org/x/text/unicode/norm"
+	"mls_salaries/pkg/mlsdata"
 )
 
-type Set struct{}
-
-func (s Set) Contains(r rune) bool {
-	return unicode.Is(unicode.Mn, r) // Mn: nonspacing marks
-}
-
 func transformDPs(s string) []string {
 	var result []string
-	var set Set
 
-	t := transform.Chain(norm.NFD, runes.Remove(set), norm.NFC)
 	names := strings.Split(s, "\n")
 	for _, n := range names {
 		name := strings.Split(n, ",")
 		if len(name) != 2 {
 			continue
 		}
-		first, _, _ := transform.String(t, name[1])
-		first = strings.TrimSpace(strings.TrimRight(first, "*"))
-		last, _, _ := transform.String(t, name[0])
-		last = strings.TrimSpace(strings.TrimRight(last, "*"))
+		first := strings.TrimSpace(strings.TrimRight(mlsdata.RemoveDiacritics(name[1]), "*"))
+		last := strings.TrimSpace(strings.TrimRight(mlsdata.RemoveDiacritics(name[0]), "*"))
 		result = append(result, fmt.Sprintf("%s %s", first, last))
 	}
 	return result